@@ -0,0 +1,134 @@
+// Package bitmapindex implements a bitmap index over low-cardinality columns.
+// Every distinct value of a column is stored as a bit set of row IDs, so
+// boolean queries are answered with word-wide set operations.
+package bitmapindex
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mostafa-asg/bit"
+)
+
+// Index is a bitmap index over a table of rows identified by non-negative IDs.
+// Each row has at most one value per column.
+type Index struct {
+	rows    *bit.Set
+	columns map[string]map[string]*bit.Set
+}
+
+// New creates an empty index.
+func New() *Index {
+	rows, _ := bit.NewSet()
+	return &Index{
+		rows:    rows,
+		columns: make(map[string]map[string]*bit.Set),
+	}
+}
+
+// Add adds the row with its column values to the index. The previous values
+// of the given columns are replaced, other columns of the row stay intact.
+func (idx *Index) Add(row int, values map[string]string) error {
+	if row < 0 {
+		return fmt.Errorf("%w: Row ID %d", bit.ErrNegativeIndex, row)
+	}
+
+	for column, value := range values {
+		idx.set(row, column, value)
+	}
+	idx.rows.Set(row)
+	return nil
+}
+
+// Set sets the value of the column for the row, replacing its previous value.
+func (idx *Index) Set(row int, column string, value string) error {
+	if row < 0 {
+		return fmt.Errorf("%w: Row ID %d", bit.ErrNegativeIndex, row)
+	}
+
+	idx.set(row, column, value)
+	idx.rows.Set(row)
+	return nil
+}
+
+// Unset removes the value of the column for the row.
+func (idx *Index) Unset(row int, column string) *Index {
+	for _, bitmap := range idx.columns[column] {
+		bitmap.Clear(row)
+	}
+	return idx
+}
+
+// Delete removes the row and all its values from the index.
+func (idx *Index) Delete(row int) *Index {
+	for column := range idx.columns {
+		idx.Unset(row, column)
+	}
+	idx.rows.Clear(row)
+	return idx
+}
+
+// Rows returns a copy of the set of all the rows in the index.
+func (idx *Index) Rows() *bit.Set {
+	return idx.rows.Clone()
+}
+
+// Columns returns the names of the indexed columns in sorted order.
+func (idx *Index) Columns() []string {
+	columns := make([]string, 0, len(idx.columns))
+	for column := range idx.columns {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// Values returns the distinct values of the column in sorted order.
+func (idx *Index) Values(column string) []string {
+	values := make([]string, 0, len(idx.columns[column]))
+	for value, bitmap := range idx.columns[column] {
+		if !bitmap.IsEmpty() {
+			values = append(values, value)
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+// Evaluate returns the set of rows matching the expression.
+func (idx *Index) Evaluate(expr Expr) *bit.Set {
+	return expr.eval(idx)
+}
+
+// Count returns the number of rows matching the expression.
+func (idx *Index) Count(expr Expr) int {
+	return expr.eval(idx).Cardinality()
+}
+
+func (idx *Index) set(row int, column string, value string) {
+	bitmaps, ok := idx.columns[column]
+	if !ok {
+		bitmaps = make(map[string]*bit.Set)
+		idx.columns[column] = bitmaps
+	}
+
+	for v, bitmap := range bitmaps {
+		if v != value {
+			bitmap.Clear(row)
+		}
+	}
+
+	bitmap, ok := bitmaps[value]
+	if !ok {
+		bitmap, _ = bit.NewSet()
+		bitmaps[value] = bitmap
+	}
+	bitmap.Set(row)
+}
+
+// newSet returns an empty set with the same size as the rows, see
+// bit.Set.CloneWithSize.
+func (idx *Index) newSet() *bit.Set {
+	set, _ := bit.NewSet(bit.WithInitialBits(idx.rows.Size()))
+	return set
+}
@@ -0,0 +1,111 @@
+package bitmapindex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestIndex(t *testing.T) *Index {
+	idx := New()
+	rows := []map[string]string{
+		{"country": "DE", "plan": "pro", "churned": "false"},
+		{"country": "DE", "plan": "team", "churned": "true"},
+		{"country": "FR", "plan": "pro", "churned": "false"},
+		{"country": "DE", "plan": "free", "churned": "false"},
+		{"country": "DE", "plan": "team", "churned": "false"},
+	}
+	for i, values := range rows {
+		if err := idx.Add(i, values); err != nil {
+			t.FailNow()
+		}
+	}
+
+	// a row far away from the others
+	if err := idx.Add(1000, map[string]string{"country": "DE", "plan": "pro"}); err != nil {
+		t.FailNow()
+	}
+	return idx
+}
+
+func TestEvaluate(t *testing.T) {
+	idx := newTestIndex(t)
+
+	testCases := []struct {
+		expr     Expr
+		expected string
+		count    int
+	}{
+		{
+			expr:     Eq("country", "DE"),
+			expected: "{0, 1, 3, 4, 1000}",
+			count:    5,
+		},
+		{
+			expr:     Eq("country", "US"),
+			expected: "{}",
+			count:    0,
+		},
+		{
+			expr:     In("plan", "pro", "team"),
+			expected: "{0, 1, 2, 4, 1000}",
+			count:    5,
+		},
+		{
+			expr: And(
+				Eq("country", "DE"),
+				Or(Eq("plan", "pro"), Eq("plan", "team")),
+				Not(Eq("churned", "true")),
+			),
+			expected: "{0, 4, 1000}",
+			count:    3,
+		},
+		{
+			expr:     Not(Eq("country", "DE")),
+			expected: "{2}",
+			count:    1,
+		},
+		{
+			expr:     And(),
+			expected: "{0, 1, 2, 3, 4, 1000}",
+			count:    6,
+		},
+		{
+			expr:     Or(),
+			expected: "{}",
+			count:    0,
+		},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.expected, idx.Evaluate(test.expr).String())
+		assert.Equal(t, test.count, idx.Count(test.expr))
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	idx := newTestIndex(t)
+
+	assert.NoError(t, idx.Set(3, "plan", "pro"))
+	assert.Equal(t, "{0, 2, 3, 1000}", idx.Evaluate(Eq("plan", "pro")).String())
+	assert.Equal(t, []string{"pro", "team"}, idx.Values("plan"))
+
+	idx.Unset(0, "plan")
+	assert.Equal(t, "{2, 3, 1000}", idx.Evaluate(Eq("plan", "pro")).String())
+	assert.Equal(t, "{0, 1, 2, 3, 4, 1000}", idx.Rows().String())
+
+	idx.Delete(1000)
+	assert.Equal(t, "{0, 1, 3, 4}", idx.Evaluate(Eq("country", "DE")).String())
+	assert.Equal(t, "{0, 1, 2, 3, 4}", idx.Rows().String())
+
+	assert.Error(t, idx.Add(-1, map[string]string{"plan": "pro"}))
+	assert.Error(t, idx.Set(-1, "plan", "pro"))
+	assert.Equal(t, []string{"churned", "country", "plan"}, idx.Columns())
+}
+
+func TestEvaluateDoesNotModifyIndex(t *testing.T) {
+	idx := newTestIndex(t)
+
+	idx.Evaluate(Eq("country", "DE")).ClearAll()
+	assert.Equal(t, 5, idx.Count(Eq("country", "DE")))
+}
@@ -0,0 +1,208 @@
+package bitmapindex
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/mostafa-asg/bit"
+)
+
+// BitSlicedIndex stores an unsigned integer column as one bit set per bit
+// position of the values, plus a set of the rows that have a value.
+// Range predicates, sums and top-k queries are answered with the algorithms
+// of O'Neil and Quass, "Improved Query Performance with Variant Indexes".
+type BitSlicedIndex struct {
+	exists *bit.Set
+	slices []*bit.Set // slices[i] holds the rows whose value has bit i set
+}
+
+// NewBitSlicedIndex creates an empty bit-sliced index.
+func NewBitSlicedIndex() *BitSlicedIndex {
+	exists, _ := bit.NewSet()
+	return &BitSlicedIndex{
+		exists: exists,
+	}
+}
+
+// SetValue sets the value of the row, replacing its previous value.
+func (bsi *BitSlicedIndex) SetValue(row int, value uint64) error {
+	if row < 0 {
+		return fmt.Errorf("%w: Row ID %d", bit.ErrNegativeIndex, row)
+	}
+
+	for len(bsi.slices) < bits.Len64(value) {
+		slice, _ := bit.NewSet()
+		bsi.slices = append(bsi.slices, slice)
+	}
+
+	for i, slice := range bsi.slices {
+		slice.SetValue(row, value&(1<<uint(i)) != 0)
+	}
+	bsi.exists.Set(row)
+	return nil
+}
+
+// Value returns the value of the row. If the row has no value, ok is false.
+func (bsi *BitSlicedIndex) Value(row int) (value uint64, ok bool) {
+	if !bsi.exists.Get(row) {
+		return 0, false
+	}
+
+	for i, slice := range bsi.slices {
+		if slice.Get(row) {
+			value |= 1 << uint(i)
+		}
+	}
+	return value, true
+}
+
+// Delete removes the value of the row.
+func (bsi *BitSlicedIndex) Delete(row int) *BitSlicedIndex {
+	for _, slice := range bsi.slices {
+		slice.Clear(row)
+	}
+	bsi.exists.Clear(row)
+	return bsi
+}
+
+// Rows returns a copy of the set of the rows that have a value.
+func (bsi *BitSlicedIndex) Rows() *bit.Set {
+	return bsi.exists.Clone()
+}
+
+// BitDepth returns the number of bit slices, which is the number of bits of
+// the largest value ever stored.
+func (bsi *BitSlicedIndex) BitDepth() int {
+	return len(bsi.slices)
+}
+
+// Equal returns the rows of the filter whose value is equal to the specified
+// value. A nil filter means all the rows.
+func (bsi *BitSlicedIndex) Equal(filter *bit.Set, value uint64) *bit.Set {
+	_, eq, _ := bsi.compare(filter, value)
+	return eq
+}
+
+// LessThan returns the rows of the filter whose value is less than the
+// specified value. A nil filter means all the rows.
+func (bsi *BitSlicedIndex) LessThan(filter *bit.Set, value uint64) *bit.Set {
+	lt, _, _ := bsi.compare(filter, value)
+	return lt
+}
+
+// GreaterThan returns the rows of the filter whose value is greater than the
+// specified value. A nil filter means all the rows.
+func (bsi *BitSlicedIndex) GreaterThan(filter *bit.Set, value uint64) *bit.Set {
+	_, _, gt := bsi.compare(filter, value)
+	return gt
+}
+
+// Between returns the rows of the filter whose value is from lo (inclusive)
+// to hi (inclusive). A nil filter means all the rows.
+func (bsi *BitSlicedIndex) Between(filter *bit.Set, lo uint64, hi uint64) *bit.Set {
+	if lo > hi {
+		return bsi.newSet()
+	}
+
+	_, eqLo, gtLo := bsi.compare(filter, lo)
+	ltHi, eqHi, _ := bsi.compare(filter, hi)
+
+	return gtLo.Or(eqLo).And(ltHi.Or(eqHi))
+}
+
+// Sum returns the sum of the values of the rows of the filter and the
+// number of those rows. A nil filter means all the rows.
+// The sum wraps around on overflow.
+func (bsi *BitSlicedIndex) Sum(filter *bit.Set) (sum uint64, count int) {
+	rows := bsi.filter(filter)
+	for i, slice := range bsi.slices {
+		sum += uint64(slice.CloneWithSize(bsi.exists.Size()).And(rows).Cardinality()) << uint(i)
+	}
+	return sum, rows.Cardinality()
+}
+
+// TopK returns the k rows of the filter with the largest values. Ties are
+// broken in favor of the smaller row IDs. If the filter has less than k
+// rows with a value, all of them are returned. A nil filter means all the rows.
+func (bsi *BitSlicedIndex) TopK(filter *bit.Set, k int) *bit.Set {
+	if k <= 0 {
+		return bsi.newSet()
+	}
+
+	// rows certainly in the result
+	greater := bsi.newSet()
+	// candidates, which are the rows with equal values so far
+	equal := bsi.filter(filter)
+
+	for i := len(bsi.slices) - 1; i >= 0; i-- {
+		slice := bsi.slices[i].CloneWithSize(bsi.exists.Size())
+		candidates := equal.Clone().And(slice)
+		x := greater.Clone().Or(candidates)
+
+		n := x.Cardinality()
+		if n > k {
+			equal = candidates
+		} else if n < k {
+			greater = x
+			equal.AndNot(slice)
+		} else {
+			greater = x
+			equal = bsi.newSet()
+			break
+		}
+	}
+
+	// fill up the rest with the candidates with the smallest row IDs
+	remaining := k - greater.Cardinality()
+	index := 0
+	for ; remaining > 0; remaining-- {
+		row, _ := equal.NextSetBit(index)
+		if row == -1 {
+			break
+		}
+		greater.Set(row)
+		index = row + 1
+	}
+
+	return greater
+}
+
+// compare returns the rows of the filter with a value less than, equal to
+// and greater than the specified value
+func (bsi *BitSlicedIndex) compare(filter *bit.Set, value uint64) (lt, eq, gt *bit.Set) {
+	lt = bsi.newSet()
+	eq = bsi.filter(filter)
+	gt = bsi.newSet()
+
+	if bits.Len64(value) > len(bsi.slices) {
+		// all the values are smaller
+		return lt.Or(eq), bsi.newSet(), gt
+	}
+
+	for i := len(bsi.slices) - 1; i >= 0; i-- {
+		slice := bsi.slices[i].CloneWithSize(bsi.exists.Size())
+		if value&(1<<uint(i)) != 0 {
+			lt.Or(eq.Clone().AndNot(slice))
+			eq.And(slice)
+		} else {
+			gt.Or(eq.Clone().And(slice))
+			eq.AndNot(slice)
+		}
+	}
+
+	return lt, eq, gt
+}
+
+// filter returns the rows of the filter that have a value
+func (bsi *BitSlicedIndex) filter(filter *bit.Set) *bit.Set {
+	rows := bsi.exists.Clone()
+	if filter != nil {
+		rows.And(filter.CloneWithSize(bsi.exists.Size()))
+	}
+	return rows
+}
+
+func (bsi *BitSlicedIndex) newSet() *bit.Set {
+	set, _ := bit.NewSet(bit.WithInitialBits(bsi.exists.Size()))
+	return set
+}
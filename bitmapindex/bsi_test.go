@@ -0,0 +1,142 @@
+package bitmapindex
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/mostafa-asg/bit"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestBSI(t *testing.T, values map[int]uint64) *BitSlicedIndex {
+	bsi := NewBitSlicedIndex()
+	for row, value := range values {
+		if err := bsi.SetValue(row, value); err != nil {
+			t.FailNow()
+		}
+	}
+	return bsi
+}
+
+func TestBitSlicedIndexValue(t *testing.T) {
+	bsi := newTestBSI(t, map[int]uint64{0: 5, 3: 0, 200: 1 << 40})
+
+	testCases := []struct {
+		row   int
+		value uint64
+		ok    bool
+	}{
+		{row: 0, value: 5, ok: true},
+		{row: 3, value: 0, ok: true},
+		{row: 200, value: 1 << 40, ok: true},
+		{row: 1, value: 0, ok: false},
+	}
+
+	for _, test := range testCases {
+		value, ok := bsi.Value(test.row)
+		assert.Equal(t, test.value, value)
+		assert.Equal(t, test.ok, ok)
+	}
+	assert.Equal(t, 41, bsi.BitDepth())
+
+	assert.NoError(t, bsi.SetValue(0, 2))
+	value, _ := bsi.Value(0)
+	assert.Equal(t, uint64(2), value)
+
+	bsi.Delete(0)
+	_, ok := bsi.Value(0)
+	assert.False(t, ok)
+	assert.Equal(t, "{3, 200}", bsi.Rows().String())
+
+	assert.Error(t, bsi.SetValue(-1, 1))
+}
+
+func TestBitSlicedIndexCompare(t *testing.T) {
+	bsi := newTestBSI(t, map[int]uint64{0: 10, 1: 20, 2: 30, 3: 20, 4: 0, 100: 25})
+	filter := bit.ValueOf([]uint64{15, 0})
+
+	assert.Equal(t, "{1, 3}", bsi.Equal(nil, 20).String())
+	assert.Equal(t, "{}", bsi.Equal(nil, 21).String())
+	assert.Equal(t, "{}", bsi.Equal(nil, 1000).String())
+	assert.Equal(t, "{0, 4}", bsi.LessThan(nil, 20).String())
+	assert.Equal(t, "{0, 1, 2, 3, 4, 100}", bsi.LessThan(nil, 1000).String())
+	assert.Equal(t, "{2, 100}", bsi.GreaterThan(nil, 20).String())
+	assert.Equal(t, "{1, 3, 100}", bsi.Between(nil, 20, 25).String())
+	assert.Equal(t, "{}", bsi.Between(nil, 25, 20).String())
+
+	assert.Equal(t, "{1, 3}", bsi.Between(filter, 20, 25).String())
+	assert.Equal(t, "{2}", bsi.GreaterThan(filter, 20).String())
+}
+
+func TestBitSlicedIndexSum(t *testing.T) {
+	bsi := newTestBSI(t, map[int]uint64{0: 10, 1: 20, 2: 30, 3: 20, 100: 25})
+
+	sum, count := bsi.Sum(nil)
+	assert.Equal(t, uint64(105), sum)
+	assert.Equal(t, 5, count)
+
+	sum, count = bsi.Sum(bit.ValueOf([]uint64{3}))
+	assert.Equal(t, uint64(30), sum)
+	assert.Equal(t, 2, count)
+}
+
+func TestBitSlicedIndexTopK(t *testing.T) {
+	bsi := newTestBSI(t, map[int]uint64{0: 10, 1: 20, 2: 30, 3: 20, 4: 0, 100: 25})
+
+	testCases := []struct {
+		filter   *bit.Set
+		k        int
+		expected string
+	}{
+		{filter: nil, k: 0, expected: "{}"},
+		{filter: nil, k: 1, expected: "{2}"},
+		{filter: nil, k: 2, expected: "{2, 100}"},
+		{filter: nil, k: 3, expected: "{1, 2, 100}"},
+		{filter: nil, k: 4, expected: "{1, 2, 3, 100}"},
+		{filter: nil, k: 10, expected: "{0, 1, 2, 3, 4, 100}"},
+		{filter: bit.ValueOf([]uint64{25}), k: 2, expected: "{0, 3}"},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.expected, bsi.TopK(test.filter, test.k).String())
+	}
+}
+
+func TestBitSlicedIndexRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	values := make(map[int]uint64)
+	for i := 0; i < 500; i++ {
+		values[r.Intn(1000)] = uint64(r.Intn(100))
+	}
+	bsi := newTestBSI(t, values)
+
+	rows := make([]int, 0, len(values))
+	for row := range values {
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if values[rows[i]] != values[rows[j]] {
+			return values[rows[i]] > values[rows[j]]
+		}
+		return rows[i] < rows[j]
+	})
+
+	for i := 0; i < 50; i++ {
+		lo, hi := uint64(r.Intn(100)), uint64(r.Intn(100))
+		expected, _ := bit.NewSet()
+		for row, value := range values {
+			if value >= lo && value <= hi {
+				expected.Set(row)
+			}
+		}
+		assert.True(t, expected.Equal(bsi.Between(nil, lo, hi)))
+
+		k := r.Intn(len(rows))
+		top, _ := bit.NewSet()
+		for _, row := range rows[:k] {
+			top.Set(row)
+		}
+		assert.True(t, top.Equal(bsi.TopK(nil, k)))
+	}
+}
@@ -0,0 +1,87 @@
+package bitmapindex
+
+import (
+	"github.com/mostafa-asg/bit"
+)
+
+// Expr is a boolean query expression over the columns of an index.
+type Expr interface {
+	eval(idx *Index) *bit.Set
+}
+
+type eqExpr struct {
+	column string
+	values []string
+}
+
+type andExpr struct {
+	exprs []Expr
+}
+
+type orExpr struct {
+	exprs []Expr
+}
+
+type notExpr struct {
+	expr Expr
+}
+
+// Eq matches the rows whose column is equal to the value.
+func Eq(column string, value string) Expr {
+	return &eqExpr{column: column, values: []string{value}}
+}
+
+// In matches the rows whose column is equal to any of the values.
+func In(column string, values ...string) Expr {
+	return &eqExpr{column: column, values: values}
+}
+
+// And matches the rows matching all of the expressions.
+// And without any expression matches all the rows.
+func And(exprs ...Expr) Expr {
+	return &andExpr{exprs: exprs}
+}
+
+// Or matches the rows matching any of the expressions.
+// Or without any expression matches no row.
+func Or(exprs ...Expr) Expr {
+	return &orExpr{exprs: exprs}
+}
+
+// Not matches the rows of the index not matching the expression.
+func Not(expr Expr) Expr {
+	return &notExpr{expr: expr}
+}
+
+func (e *eqExpr) eval(idx *Index) *bit.Set {
+	result := idx.newSet()
+	for _, value := range e.values {
+		if bitmap, ok := idx.columns[e.column][value]; ok {
+			result.Or(bitmap)
+		}
+	}
+	return result
+}
+
+func (e *andExpr) eval(idx *Index) *bit.Set {
+	result := idx.rows.Clone()
+	for _, expr := range e.exprs {
+		if result.IsEmpty() {
+			break
+		}
+		result.And(expr.eval(idx))
+	}
+	return result
+}
+
+func (e *orExpr) eval(idx *Index) *bit.Set {
+	result := idx.newSet()
+	for _, expr := range e.exprs {
+		result.Or(expr.eval(idx))
+	}
+	return result
+}
+
+func (e *notExpr) eval(idx *Index) *bit.Set {
+	return idx.rows.Clone().AndNot(e.expr.eval(idx))
+}
@@ -3,8 +3,8 @@ package bit
 import (
 	"bytes"
 	"encoding/binary"
-	"errors"
 	"fmt"
+	"math/bits"
 	"strconv"
 )
 
@@ -16,6 +16,21 @@ const (
 
 type Set struct {
 	arr []uint64
+
+	// maximum number of bits, zero means unlimited
+	maxBits int
+	// what to do on writes past maxBits
+	policy BoundPolicy
+	// whether misuse such as negative indices panics instead of being ignored
+	strict bool
+	// unused bits after which the set is compacted on clears, zero means never
+	shrinkBits int
+	// number of words reserved on creation, the set never shrinks below it
+	minWords int
+
+	// optional auxiliary index for rank and select queries,
+	// it is dropped on every mutation
+	rank *rankIndex
 }
 
 func NewSet(options ...Option) (*Set, error) {
@@ -32,15 +47,33 @@ func NewSet(options ...Option) (*Set, error) {
 
 func newSet(opts *Options) (*Set, error) {
 	if opts.nbits < 0 {
-		return nil, errors.New("Number of bits is negative")
+		return nil, fmt.Errorf("%w: Number of bits is negative", ErrInvalidArgument)
+	}
+
+	if opts.maxBits < 0 {
+		return nil, fmt.Errorf("%w: Maximum number of bits is negative", ErrInvalidArgument)
+	}
+
+	if opts.shrinkBits < 0 {
+		return nil, fmt.Errorf("%w: Auto-shrink threshold is negative", ErrInvalidArgument)
 	}
 
 	if opts.nbits == 0 {
 		opts.nbits = minBits
 	}
 
+	if opts.maxBits > 0 && opts.nbits > opts.maxBits {
+		opts.nbits = opts.maxBits
+	}
+
+	words := howManyUint64(opts.nbits)
 	return &Set{
-		arr: make([]uint64, howManyUint64(opts.nbits)),
+		arr:        make([]uint64, words),
+		maxBits:    opts.maxBits,
+		policy:     opts.policy,
+		strict:     opts.strict,
+		shrinkBits: opts.shrinkBits,
+		minWords:   words,
 	}, nil
 }
 
@@ -76,25 +109,36 @@ func FromByteArray(nums []byte) *Set {
 }
 
 // Flip sets the bit at the specified index to the complement of its current value.
-// If index is negative no change will happen.
+// If index is negative no change will happen, or it panics in strict mode.
+// If index is past the bound of the set, the bound policy is applied.
 func (set *Set) Flip(index int) *Set {
 	if index < 0 {
-		// do nothing
+		set.misuse(fmt.Errorf("%w: %d", ErrNegativeIndex, index))
+		return set
+	}
+
+	if !set.inBounds(index) {
+		set.outOfBounds(index)
 		return set
 	}
 
 	arrIndex, bitIndex := set.locate(index)
+	set.invalidate()
 	set.arr[arrIndex] = set.arr[arrIndex] ^ (1 << bitIndex)
 	return set
 }
 
 // FlipRange sets each bit from the specified fromIndex (inclusive)
 // to the specified toIndex (exclusive) to the complement of its current value.
+// A negative fromIndex is treated as zero and a reversed range changes
+// nothing, both panic in strict mode instead.
 func (set *Set) FlipRange(fromIndex int, toIndex int) *Set {
-	if fromIndex < 0 {
-		fromIndex = 0
+	fromIndex, toIndex, ok := set.checkRange(fromIndex, toIndex)
+	if !ok {
+		return set
 	}
 
+	toIndex = set.boundRange(toIndex)
 	for i := fromIndex; i < toIndex; i++ {
 		set.Flip(i)
 	}
@@ -102,49 +146,71 @@ func (set *Set) FlipRange(fromIndex int, toIndex int) *Set {
 }
 
 // Clear sets the bit specified by the index to false.
-// If index is negative no change will happen.
+// If index is negative no change will happen, or it panics in strict mode.
+// If index is past the bound of the set, the bound policy is applied.
 func (set *Set) Clear(index int) *Set {
 	if index < 0 {
-		// do nothing
+		set.misuse(fmt.Errorf("%w: %d", ErrNegativeIndex, index))
+		return set
+	}
+
+	if !set.inBounds(index) {
+		set.outOfBounds(index)
 		return set
 	}
 
 	arrIndex, bitIndex := set.locate(index)
+	set.invalidate()
 	set.arr[arrIndex] = set.arr[arrIndex] & (^(1 << bitIndex))
 	return set
 }
 
 // ClearRange sets the bits from the specified fromIndex (inclusive)
 // to the specified toIndex (exclusive) to false.
+// A negative fromIndex is treated as zero and a reversed range changes
+// nothing, both panic in strict mode instead.
 func (set *Set) ClearRange(fromIndex int, toIndex int) *Set {
-	if fromIndex < 0 {
-		fromIndex = 0
+	fromIndex, toIndex, ok := set.checkRange(fromIndex, toIndex)
+	if !ok {
+		return set
 	}
 
+	toIndex = set.boundRange(toIndex)
 	for i := fromIndex; i < toIndex; i++ {
 		set.Clear(i)
 	}
 
+	set.shrinkIfNeeded()
 	return set
 }
 
 // ClearAll sets all of the bits in this BitSet to false.
 func (set *Set) ClearAll() *Set {
+	set.invalidate()
 	for i := range set.arr {
 		set.arr[i] = 0
 	}
+
+	set.shrinkIfNeeded()
 	return set
 }
 
 // Set sets the bit at the specified index to true.
-// If index is negative no change will happen.
+// If index is negative no change will happen, or it panics in strict mode.
+// If index is past the bound of the set, the bound policy is applied.
 func (set *Set) Set(index int) *Set {
 	if index < 0 {
-		// do nothing
+		set.misuse(fmt.Errorf("%w: %d", ErrNegativeIndex, index))
+		return set
+	}
+
+	if !set.inBounds(index) {
+		set.outOfBounds(index)
 		return set
 	}
 
 	arrIndex, bitIndex := set.locate(index)
+	set.invalidate()
 	set.arr[arrIndex] = set.arr[arrIndex] | (1 << bitIndex)
 	return set
 }
@@ -160,12 +226,15 @@ func (set *Set) SetValue(index int, value bool) *Set {
 
 // SetRange sets the bits from the specified fromIndex (inclusive)
 // to the specified toIndex (exclusive) to true.
+// A negative fromIndex is treated as zero and a reversed range changes
+// nothing, both panic in strict mode instead.
 func (set *Set) SetRange(fromIndex int, toIndex int) *Set {
-	if fromIndex < 0 {
-		// do nothing
+	fromIndex, toIndex, ok := set.checkRange(fromIndex, toIndex)
+	if !ok {
 		return set
 	}
 
+	toIndex = set.boundRange(toIndex)
 	for i := fromIndex; i < toIndex; i++ {
 		set.Set(i)
 	}
@@ -175,11 +244,15 @@ func (set *Set) SetRange(fromIndex int, toIndex int) *Set {
 
 // SetRangeValue sets the bits from the specified fromIndex (inclusive)
 // to the specified toIndex (exclusive) to the specified value.
+// A negative fromIndex is treated as zero and a reversed range changes
+// nothing, both panic in strict mode instead.
 func (set *Set) SetRangeValue(fromIndex int, toIndex int, value bool) *Set {
-	if fromIndex < 0 {
-		fromIndex = 0
+	fromIndex, toIndex, ok := set.checkRange(fromIndex, toIndex)
+	if !ok {
+		return set
 	}
 
+	toIndex = set.boundRange(toIndex)
 	for i := fromIndex; i < toIndex; i++ {
 		set.SetValue(i, value)
 	}
@@ -201,23 +274,121 @@ func (set *Set) Get(index int) bool {
 }
 
 // GetRange returns a new BitSet composed of bits from this BitSet from fromIndex (inclusive)
-// to toIndex (exclusive).
+// to toIndex (exclusive). A negative fromIndex is treated as zero and a reversed
+// range returns an empty set, both panic in strict mode instead.
 func (set *Set) GetRange(fromIndex int, toIndex int) *Set {
-	result, _ := NewSet()
-	resultIndex := 0
+	fromIndex, toIndex, _ = set.checkRange(fromIndex, toIndex)
 
-	if fromIndex < 0 {
-		fromIndex = 0
-	}
+	result, _ := NewSet(WithInitialBits(toIndex - fromIndex))
 
-	for i := fromIndex; i < toIndex; i++ {
-		result.SetValue(resultIndex, set.Get(i))
-		resultIndex++
+	// copy a word at a time
+	for i := 0; i < howManyUint64(toIndex-fromIndex); i++ {
+		width := min(minBits, toIndex-fromIndex-i*minBits)
+		result.arr[i] = set.GetBits(fromIndex+i*minBits, width)
 	}
 
 	return result
 }
 
+// GetBits returns the unsigned field of width bits (at most 64) starting at
+// the offset, where the bit at the offset is the least significant bit of the
+// field. The field may cross word boundaries.
+// If offset is negative or width is not between 1 and 64, zero is returned,
+// or it panics in strict mode.
+func (set *Set) GetBits(offset int, width int) uint64 {
+	if !set.checkField(offset, width) {
+		return 0
+	}
+
+	arrIndex, shift := offset/minBits, uint(offset%minBits)
+	if arrIndex >= len(set.arr) {
+		// all is clear outside boundary
+		return 0
+	}
+
+	value := set.arr[arrIndex] >> shift
+	if int(shift)+width > minBits && arrIndex+1 < len(set.arr) {
+		value |= set.arr[arrIndex+1] << (minBits - shift)
+	}
+
+	if width < minBits {
+		value &= 1<<uint(width) - 1
+	}
+	return value
+}
+
+// GetSignedBits returns the two's complement signed field of width bits
+// (at most 64) starting at the offset.
+// If offset is negative or width is not between 1 and 64, zero is returned,
+// or it panics in strict mode.
+func (set *Set) GetSignedBits(offset int, width int) int64 {
+	if !set.checkField(offset, width) {
+		return 0
+	}
+
+	// sign extension
+	shift := uint(minBits - width)
+	return int64(set.GetBits(offset, width)<<shift) >> shift
+}
+
+// SetBits sets the field of width bits (at most 64) starting at the offset
+// to the lowest width bits of the value, where the bit at the offset receives
+// the least significant bit of the value. The field may cross word boundaries.
+// If offset is negative or width is not between 1 and 64 no change will happen,
+// or it panics in strict mode.
+// If the field crosses the bound of the set, the bound policy is applied and
+// the part within the bound is written.
+func (set *Set) SetBits(offset int, width int, value uint64) *Set {
+	if !set.checkField(offset, width) {
+		return set
+	}
+
+	if !set.inBounds(offset + width - 1) {
+		set.outOfBounds(offset + width - 1)
+	}
+
+	return set.writeField(offset, width, value)
+}
+
+// checkField validates the field of the *Bits methods. It returns false if
+// offset is negative or width is not between 1 and 64, so nothing should be
+// read or written.
+func (set *Set) checkField(offset int, width int) bool {
+	if offset < 0 {
+		set.misuse(fmt.Errorf("%w: %d", ErrNegativeIndex, offset))
+		return false
+	}
+	if width < 1 || width > minBits {
+		set.misuse(fmt.Errorf("%w: Number of bits should be between 1 and 64: %d", ErrInvalidArgument, width))
+		return false
+	}
+
+	return true
+}
+
+// writeField writes the field like SetBits, but the part of the field past
+// the bound of the set is silently dropped
+func (set *Set) writeField(offset int, width int, value uint64) *Set {
+	if set.maxBits > 0 {
+		if offset >= set.maxBits {
+			return set
+		}
+		width = min(width, set.maxBits-offset)
+	}
+
+	set.invalidate()
+	set.expandIfNeeded((offset + width - 1) / minBits)
+	writeBits(set.arr, offset, width, value)
+	return set
+}
+
+// SetSignedBits sets the field of width bits (at most 64) starting at the
+// offset to the value in two's complement. The value is truncated if it
+// doesn't fit in the field.
+func (set *Set) SetSignedBits(offset int, width int, value int64) *Set {
+	return set.SetBits(offset, width, uint64(value))
+}
+
 // Size returns the number of bits of space actually in use by this BitSet
 // to represent bit values.
 func (set *Set) Size() int {
@@ -236,15 +407,15 @@ func (set *Set) Length() int {
 }
 
 // Cardinality returns the number of bits set to true in this BitSet.
+// It runs in O(words), or in O(1) when the rank and select index is built.
 func (set *Set) Cardinality() int {
-	count := 0
+	if set.rank != nil {
+		return set.rank.ones
+	}
 
+	count := 0
 	for _, item := range set.arr {
-		n := item
-		for n > 0 {
-			n = n & (n - 1)
-			count++
-		}
+		count += bits.OnesCount64(item)
 	}
 
 	return count
@@ -255,12 +426,14 @@ func (set *Set) Cardinality() int {
 // it both initially had the value true and the corresponding bit in the bit set
 // argument also had the value true.
 func (set *Set) And(otherSet *Set) *Set {
+	set.invalidate()
 	length := min(len(set.arr), len(otherSet.arr))
 
 	for i := 0; i < length; i++ {
 		set.arr[i] &= otherSet.arr[i]
 	}
 
+	set.shrinkIfNeeded()
 	return set
 }
 
@@ -277,23 +450,27 @@ func (set *Set) AndNot(otherSet *Set) *Set {
 // it either already had the value true or the corresponding bit in the
 // bit set argument has the value true.
 func (set *Set) Or(otherSet *Set) *Set {
+	set.invalidate()
 	length := min(len(set.arr), len(otherSet.arr))
 
 	for i := 0; i < length; i++ {
 		set.arr[i] |= otherSet.arr[i]
 	}
 
+	set.clearOutOfBounds()
 	return set
 }
 
 // Xor performs a logical XOR of this bit set with the bit set argument.
 func (set *Set) Xor(otherSet *Set) *Set {
+	set.invalidate()
 	length := min(len(set.arr), len(otherSet.arr))
 
 	for i := 0; i < length; i++ {
 		set.arr[i] ^= otherSet.arr[i]
 	}
 
+	set.clearOutOfBounds()
 	return set
 }
 
@@ -329,6 +506,11 @@ func (set *Set) Equal(otherSet *Set) bool {
 // Clone creates a new copy of the current set
 func (set *Set) Clone() *Set {
 	copySet, _ := NewSet(WithInitialBits(len(set.arr) * minBits))
+	copySet.maxBits = set.maxBits
+	copySet.policy = set.policy
+	copySet.strict = set.strict
+	copySet.shrinkBits = set.shrinkBits
+	copySet.minWords = set.minWords
 
 	for i, item := range set.arr {
 		copySet.arr[i] = item
@@ -337,6 +519,27 @@ func (set *Set) Clone() *Set {
 	return copySet
 }
 
+// CloneWithSize returns a copy of the set whose Size() is nbits rounded up
+// to a multiple of 64, and the bits past it are dropped. As Or, And and Xor
+// only combine the common words of both sets, operands and results which are
+// cloned to the same size can be combined without losing any bit. If nbits is
+// negative it is treated as zero, or it panics in strict mode.
+func (set *Set) CloneWithSize(nbits int) *Set {
+	if nbits < 0 {
+		set.misuse(fmt.Errorf("%w: Number of bits is negative: %d", ErrInvalidArgument, nbits))
+		nbits = 0
+	}
+
+	copySet, _ := NewSet(WithInitialBits(nbits), WithMaxBits(set.maxBits))
+	copySet.policy = set.policy
+	copySet.strict = set.strict
+	copySet.shrinkBits = set.shrinkBits
+	copy(copySet.arr, set.arr)
+	copySet.clearOutOfBounds()
+
+	return copySet
+}
+
 // ToArray returns a new array containing all the bits in this bit set.
 func (set *Set) ToArray() []uint64 {
 	result := make([]uint64, len(set.arr))
@@ -440,7 +643,7 @@ func (set *Set) String() string {
 
 func (set *Set) previousBitIndex(fromIndex int, value bool) (int, error) {
 	if fromIndex < -1 {
-		return -1, fmt.Errorf("Index is negative: %d", fromIndex)
+		return -1, fmt.Errorf("%w: %d", ErrNegativeIndex, fromIndex)
 	}
 
 	if fromIndex == -1 {
@@ -460,18 +663,25 @@ func (set *Set) previousBitIndex(fromIndex int, value bool) (int, error) {
 		}
 	}
 
-	for i := fromIndex; i >= 0; i-- {
-		if set.Get(i) == value {
-			return i, nil
+	// search a word at a time, clear bits are searched as set bits of the complement
+	arrIndex := fromIndex / minBits
+	word := set.wordOf(arrIndex, value) & (^uint64(0) >> uint(minBits-1-fromIndex%minBits))
+	for {
+		if word != 0 {
+			return arrIndex*minBits + minBits - 1 - bits.LeadingZeros64(word), nil
 		}
-	}
 
-	return -1, nil
+		arrIndex--
+		if arrIndex < 0 {
+			return -1, nil
+		}
+		word = set.wordOf(arrIndex, value)
+	}
 }
 
 func (set *Set) nextBitIndex(fromIndex int, value bool) (int, error) {
 	if fromIndex < 0 {
-		return -1, fmt.Errorf("Index should be positive: %d", fromIndex)
+		return -1, fmt.Errorf("%w: %d", ErrNegativeIndex, fromIndex)
 	}
 
 	lastIndex := len(set.arr)*minBits - 1
@@ -486,10 +696,19 @@ func (set *Set) nextBitIndex(fromIndex int, value bool) (int, error) {
 		return fromIndex, nil
 	}
 
-	for i := fromIndex; i <= lastIndex; i++ {
-		if set.Get(i) == value {
-			return i, nil
+	// search a word at a time, clear bits are searched as set bits of the complement
+	arrIndex := fromIndex / minBits
+	word := set.wordOf(arrIndex, value) & (^uint64(0) << uint(fromIndex%minBits))
+	for {
+		if word != 0 {
+			return arrIndex*minBits + bits.TrailingZeros64(word), nil
 		}
+
+		arrIndex++
+		if arrIndex == len(set.arr) {
+			break
+		}
+		word = set.wordOf(arrIndex, value)
 	}
 
 	if value {
@@ -502,6 +721,11 @@ func (set *Set) nextBitIndex(fromIndex int, value bool) (int, error) {
 }
 
 func (set *Set) expandIfNeeded(arrIndex int) {
+	if set.maxBits > 0 {
+		// never grow past the bound
+		arrIndex = min(arrIndex, howManyUint64(set.maxBits)-1)
+	}
+
 	lastIndexNum := len(set.arr) - 1
 	if arrIndex > lastIndexNum {
 		itemsNeeded := arrIndex - lastIndexNum
@@ -511,6 +735,152 @@ func (set *Set) expandIfNeeded(arrIndex int) {
 	}
 }
 
+// wordOf returns the word at arrIndex if value is true, or its complement
+// if value is false
+func (set *Set) wordOf(arrIndex int, value bool) uint64 {
+	if value {
+		return set.arr[arrIndex]
+	}
+	return ^set.arr[arrIndex]
+}
+
+// Iterate calls fn with the index of every set bit in ascending order, until
+// fn returns false. It skips the clear bits a word at a time, so it runs in
+// O(words + set bits).
+func (set *Set) Iterate(fn func(index int) bool) {
+	for arrIndex, word := range set.arr {
+		for word != 0 {
+			if !fn(arrIndex*minBits + bits.TrailingZeros64(word)) {
+				return
+			}
+			word &= word - 1 // clear the lowest set bit
+		}
+	}
+}
+
+// MaxBits returns the maximum number of bits of the set,
+// zero means unlimited.
+func (set *Set) MaxBits() int {
+	return set.maxBits
+}
+
+// TrySet sets the bit at the specified index to true, or returns an error
+// if index is negative or past the bound of the set.
+func (set *Set) TrySet(index int) error {
+	if err := set.checkIndex(index); err != nil {
+		return err
+	}
+
+	set.Set(index)
+	return nil
+}
+
+// TryClear sets the bit at the specified index to false, or returns an error
+// if index is negative or past the bound of the set.
+func (set *Set) TryClear(index int) error {
+	if err := set.checkIndex(index); err != nil {
+		return err
+	}
+
+	set.Clear(index)
+	return nil
+}
+
+// TryFlip flips the bit at the specified index, or returns an error
+// if index is negative or past the bound of the set.
+func (set *Set) TryFlip(index int) error {
+	if err := set.checkIndex(index); err != nil {
+		return err
+	}
+
+	set.Flip(index)
+	return nil
+}
+
+func (set *Set) checkIndex(index int) error {
+	if index < 0 {
+		return fmt.Errorf("%w: %d", ErrNegativeIndex, index)
+	}
+
+	if !set.inBounds(index) {
+		return fmt.Errorf("%w: %d", ErrOutOfBounds, index)
+	}
+
+	return nil
+}
+
+// inBounds checks whether the index is within the bound of the set
+func (set *Set) inBounds(index int) bool {
+	return set.maxBits == 0 || index < set.maxBits
+}
+
+// outOfBounds applies the bound policy on a write past the bound
+func (set *Set) outOfBounds(index int) {
+	if set.policy == PanicOutOfBounds {
+		panic(fmt.Errorf("%w: %d is not in [0, %d)", ErrOutOfBounds, index, set.maxBits))
+	}
+}
+
+// IsStrict checks whether misuse such as negative indices or reversed ranges
+// panics, instead of being ignored.
+func (set *Set) IsStrict() bool {
+	return set.strict
+}
+
+// misuse panics with the error in strict mode, and does nothing otherwise
+func (set *Set) misuse(err error) {
+	if set.strict {
+		panic(err)
+	}
+}
+
+// checkRange validates the range of the *Range methods, a negative fromIndex
+// is treated as zero. It returns false if the range is reversed,
+// so nothing should change.
+func (set *Set) checkRange(fromIndex int, toIndex int) (int, int, bool) {
+	if fromIndex < 0 {
+		set.misuse(fmt.Errorf("%w: %d", ErrNegativeIndex, fromIndex))
+		fromIndex = 0
+	}
+	if toIndex < fromIndex {
+		set.misuse(fmt.Errorf("%w: [%d, %d)", ErrInvalidRange, fromIndex, toIndex))
+		return fromIndex, fromIndex, false
+	}
+
+	return fromIndex, toIndex, true
+}
+
+// boundRange returns the exclusive end of a range limited to the bound of
+// the set, and applies the bound policy if the range crosses the bound
+func (set *Set) boundRange(toIndex int) int {
+	if set.maxBits > 0 && toIndex > set.maxBits {
+		set.outOfBounds(toIndex - 1)
+		return set.maxBits
+	}
+
+	return toIndex
+}
+
+// clearOutOfBounds clears the bits past the bound of the set
+func (set *Set) clearOutOfBounds() {
+	if set.maxBits == 0 || len(set.arr)*minBits <= set.maxBits {
+		return
+	}
+
+	lastIndex := howManyUint64(set.maxBits) - 1
+	if rem := set.maxBits % minBits; rem != 0 {
+		set.arr[lastIndex] &= 1<<uint(rem) - 1
+	}
+	for i := lastIndex + 1; i < len(set.arr); i++ {
+		set.arr[i] = 0
+	}
+}
+
+// invalidate drops the auxiliary indexes, it must be called on every mutation
+func (set *Set) invalidate() {
+	set.rank = nil
+}
+
 // locate find the index within the array, it also expands the array
 // if index is out of range
 func (set *Set) locate(index int) (arrIndex int, bitIndex int) {
@@ -538,3 +908,50 @@ func min(a, b int) int {
 
 	return b
 }
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+// readBits reads a field of width bits (at most 64) starting at the bit
+// offset of the array, the field may cross a word boundary
+func readBits(arr []uint64, offset int, width int) uint64 {
+	if width == 0 {
+		return 0
+	}
+
+	arrIndex, shift := offset/minBits, uint(offset%minBits)
+	value := arr[arrIndex] >> shift
+	if int(shift)+width > minBits {
+		value |= arr[arrIndex+1] << (minBits - shift)
+	}
+
+	if width < minBits {
+		value &= 1<<uint(width) - 1
+	}
+	return value
+}
+
+// writeBits writes the lowest width bits (at most 64) of the value starting
+// at the bit offset of the array, the field may cross a word boundary
+func writeBits(arr []uint64, offset int, width int, value uint64) {
+	if width == 0 {
+		return
+	}
+
+	mask := ^uint64(0)
+	if width < minBits {
+		mask = 1<<uint(width) - 1
+	}
+	value &= mask
+
+	arrIndex, shift := offset/minBits, uint(offset%minBits)
+	arr[arrIndex] = arr[arrIndex]&^(mask<<shift) | value<<shift
+	if int(shift)+width > minBits {
+		arr[arrIndex+1] = arr[arrIndex+1]&^(mask>>(minBits-shift)) | value>>(minBits-shift)
+	}
+}
@@ -1,6 +1,7 @@
 package bit
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -905,6 +906,18 @@ func TestGetRange(t *testing.T) {
 			toIndex:   10,
 			expected:  ValueOf([]uint64{0}),
 		},
+		{
+			set:       ValueOf([]uint64{15, 7}),
+			fromIndex: 2,
+			toIndex:   130,
+			expected:  ValueOf([]uint64{3 | 3<<62, 1}),
+		},
+		{
+			set:       ValueOf([]uint64{15}),
+			fromIndex: 4,
+			toIndex:   2,
+			expected:  ValueOf([]uint64{0}),
+		},
 	}
 
 	for _, test := range testCases {
@@ -912,3 +925,296 @@ func TestGetRange(t *testing.T) {
 		assert.True(t, test.expected.Equal(result))
 	}
 }
+
+func TestGetBits(t *testing.T) {
+	set := ValueOf([]uint64{0xF00000000000000F, 0xA5})
+
+	testCases := []struct {
+		offset   int
+		width    int
+		expected uint64
+	}{
+		{offset: 0, width: 4, expected: 0xF},
+		{offset: 2, width: 4, expected: 0x3},
+		{offset: 60, width: 8, expected: 0x5F},
+		{offset: 60, width: 12, expected: 0xA5F},
+		{offset: 0, width: 64, expected: 0xF00000000000000F},
+		{offset: 64, width: 64, expected: 0xA5},
+		{offset: 120, width: 16, expected: 0},
+		{offset: 1000, width: 8, expected: 0},
+		{offset: -1, width: 8, expected: 0},
+		{offset: 0, width: 0, expected: 0},
+		{offset: 0, width: 65, expected: 0},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.expected, set.GetBits(test.offset, test.width))
+	}
+}
+
+func TestSetBits(t *testing.T) {
+	set, err := NewSet()
+	if err != nil {
+		t.FailNow()
+	}
+
+	set.SetBits(60, 12, 0xA5F)
+	assert.True(t, ValueOf([]uint64{0xF000000000000000, 0xA5}).Equal(set))
+
+	// only the lowest bits of the value are used
+	set.SetBits(62, 4, 0xF0)
+	assert.True(t, ValueOf([]uint64{0x3000000000000000, 0xA4}).Equal(set))
+
+	set.SetBits(200, 64, ^uint64(0))
+	assert.Equal(t, 5*minBits, set.Size())
+	assert.Equal(t, ^uint64(0), set.GetBits(200, 64))
+
+	// should have no side effect
+	set.SetBits(-1, 8, 0xFF)
+	set.SetBits(0, 0, 0xFF)
+	set.SetBits(0, 65, 0xFF)
+	assert.Equal(t, uint64(0), set.GetBits(0, 8))
+}
+
+func TestSignedBits(t *testing.T) {
+	set, err := NewSet()
+	if err != nil {
+		t.FailNow()
+	}
+
+	testCases := []struct {
+		offset int
+		width  int
+		value  int64
+	}{
+		{offset: 0, width: 12, value: -1},
+		{offset: 12, width: 12, value: -2048},
+		{offset: 24, width: 12, value: 2047},
+		{offset: 58, width: 12, value: -5},
+		{offset: 100, width: 64, value: -1 << 63},
+		{offset: 170, width: 1, value: -1},
+	}
+
+	for _, test := range testCases {
+		set.SetSignedBits(test.offset, test.width, test.value)
+	}
+	for _, test := range testCases {
+		assert.Equal(t, test.value, set.GetSignedBits(test.offset, test.width))
+	}
+
+	assert.Equal(t, uint64(0xFFF), set.GetBits(0, 12))
+	assert.Equal(t, int64(0), set.GetSignedBits(0, 0))
+}
+
+func TestBoundedSet(t *testing.T) {
+	set, err := NewSet(WithMaxBits(100))
+	if err != nil {
+		t.FailNow()
+	}
+	assert.Equal(t, 100, set.MaxBits())
+	assert.Equal(t, minBits, set.Size())
+
+	set.Set(99)
+	assert.True(t, set.Get(99))
+	assert.Equal(t, 2*minBits, set.Size())
+
+	// ignored by default
+	set.Set(100).Flip(1 << 30).Clear(200)
+	assert.False(t, set.Get(100))
+	assert.Equal(t, 2*minBits, set.Size())
+
+	set.SetRange(90, 1<<30)
+	assert.Equal(t, 10, set.Cardinality())
+	set.FlipRange(95, 1<<30)
+	assert.Equal(t, 5, set.Cardinality())
+	set.SetBits(96, 8, 0xFF)
+	assert.Equal(t, 9, set.Cardinality())
+	assert.Equal(t, 2*minBits, set.Size())
+
+	_, err = NewSet(WithMaxBits(-1))
+	assert.Error(t, err)
+}
+
+func TestFixedSizeSet(t *testing.T) {
+	set, err := NewSet(WithFixedSize(130))
+	if err != nil {
+		t.FailNow()
+	}
+	assert.Equal(t, 3*minBits, set.Size())
+
+	set.Set(129).ShiftLeft(1)
+	assert.True(t, set.IsEmpty())
+	assert.Equal(t, 3*minBits, set.Size())
+
+	set.Set(0).InsertRange(0, 200, true)
+	assert.Equal(t, 130, set.Cardinality())
+
+	clone := set.Clone()
+	assert.Equal(t, 130, clone.MaxBits())
+	clone.Set(500)
+	assert.Equal(t, 3*minBits, clone.Size())
+}
+
+func TestBoundPolicy(t *testing.T) {
+	set, err := NewSet(WithFixedSize(10), WithBoundPolicy(PanicOutOfBounds))
+	if err != nil {
+		t.FailNow()
+	}
+
+	assert.NotPanics(t, func() { set.Set(9) })
+	assert.Panics(t, func() { set.Set(10) })
+	assert.Panics(t, func() { set.Clear(10) })
+	assert.Panics(t, func() { set.Flip(10) })
+	assert.Panics(t, func() { set.SetRange(0, 11) })
+	assert.Panics(t, func() { set.SetBits(8, 4, 0) })
+	assert.NotPanics(t, func() { set.ClearRange(0, 10) })
+}
+
+func TestTrySet(t *testing.T) {
+	set, err := NewSet(WithFixedSize(10))
+	if err != nil {
+		t.FailNow()
+	}
+
+	assert.NoError(t, set.TrySet(9))
+	assert.True(t, set.Get(9))
+	assert.NoError(t, set.TryFlip(9))
+	assert.False(t, set.Get(9))
+	assert.NoError(t, set.TryClear(9))
+
+	assert.ErrorIs(t, set.TrySet(10), ErrOutOfBounds)
+	assert.Error(t, set.TryClear(10))
+	assert.Error(t, set.TryFlip(10))
+	assert.ErrorIs(t, set.TrySet(-1), ErrNegativeIndex)
+
+	unbounded, _ := NewSet()
+	assert.NoError(t, unbounded.TrySet(1000))
+	assert.True(t, unbounded.Get(1000))
+}
+
+func TestRangeValidation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		update   func(set *Set)
+		expected string
+	}{
+		{"SetRange negative from", func(set *Set) { set.SetRange(-3, 2) }, "{0, 1}"},
+		{"ClearRange negative from", func(set *Set) { set.SetRange(0, 4).ClearRange(-3, 2) }, "{2, 3}"},
+		{"FlipRange negative from", func(set *Set) { set.FlipRange(-3, 2) }, "{0, 1}"},
+		{"SetRangeValue negative from", func(set *Set) { set.SetRangeValue(-3, 2, true) }, "{0, 1}"},
+		{"SetRange reversed", func(set *Set) { set.SetRange(5, 2) }, "{}"},
+		{"ClearRange reversed", func(set *Set) { set.SetRange(0, 4).ClearRange(3, 1) }, "{0, 1, 2, 3}"},
+		{"DeleteRange negative from", func(set *Set) { set.SetRange(0, 4).DeleteRange(-3, 2) }, "{0, 1}"},
+		{"DeleteRange reversed", func(set *Set) { set.SetRange(0, 4).DeleteRange(3, 1) }, "{0, 1, 2, 3}"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			set, _ := NewSet()
+			tc.update(set)
+			assert.Equal(t, tc.expected, set.String())
+		})
+	}
+}
+
+func TestStrictMode(t *testing.T) {
+	set, err := NewSet(WithStrictMode())
+	if err != nil {
+		t.FailNow()
+	}
+	assert.True(t, set.IsStrict())
+	assert.True(t, set.Clone().IsStrict())
+
+	assertPanicsWith := func(target error, fn func()) {
+		defer func() {
+			err, ok := recover().(error)
+			if assert.True(t, ok) {
+				assert.True(t, errors.Is(err, target), err.Error())
+			}
+		}()
+		fn()
+	}
+
+	assertPanicsWith(ErrNegativeIndex, func() { set.Set(-1) })
+	assertPanicsWith(ErrNegativeIndex, func() { set.Clear(-1) })
+	assertPanicsWith(ErrNegativeIndex, func() { set.Flip(-1) })
+	assertPanicsWith(ErrNegativeIndex, func() { set.SetRange(-1, 2) })
+	assertPanicsWith(ErrNegativeIndex, func() { set.GetRange(-1, 2) })
+	assertPanicsWith(ErrInvalidRange, func() { set.ClearRange(3, 1) })
+	assertPanicsWith(ErrInvalidRange, func() { set.DeleteRange(3, 1) })
+	assertPanicsWith(ErrNegativeIndex, func() { set.SetBits(-1, 4, 0) })
+	assertPanicsWith(ErrInvalidArgument, func() { set.SetBits(0, 65, 0) })
+	assertPanicsWith(ErrInvalidArgument, func() { set.SetBits(0, 0, 0) })
+	assertPanicsWith(ErrNegativeIndex, func() { set.GetBits(-1, 4) })
+	assertPanicsWith(ErrInvalidArgument, func() { set.GetBits(0, 0) })
+	assertPanicsWith(ErrNegativeIndex, func() { set.GetSignedBits(-1, 4) })
+	assertPanicsWith(ErrInvalidArgument, func() { set.GetSignedBits(0, 65) })
+	assertPanicsWith(ErrInvalidArgument, func() { set.ShiftLeft(-1) })
+	assertPanicsWith(ErrInvalidArgument, func() { set.InsertRange(0, -1, true) })
+
+	assert.NotPanics(t, func() { set.SetRange(2, 2).Set(0).Get(-1) })
+	assert.NotPanics(t, func() { set.GetRange(2, 2) })
+	assert.Equal(t, "{0}", set.String())
+
+	bounded, _ := NewSet(WithFixedSize(10), WithBoundPolicy(PanicOutOfBounds))
+	assertPanicsWith(ErrOutOfBounds, func() { bounded.Set(10) })
+}
+
+func TestErrors(t *testing.T) {
+	_, err := NewSet(WithInitialBits(-1))
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	set, _ := NewSet()
+	_, err = set.NextSetBit(-1)
+	assert.ErrorIs(t, err, ErrNegativeIndex)
+	_, err = set.NextClearBit(-1)
+	assert.ErrorIs(t, err, ErrNegativeIndex)
+	_, err = set.PreviousSetBit(-2)
+	assert.ErrorIs(t, err, ErrNegativeIndex)
+	assert.EqualError(t, err, "Index is negative: -2")
+}
+
+func TestIterate(t *testing.T) {
+	set, _ := FromIndices([]int{0, 63, 64, 1000})
+
+	var indices []int
+	set.Iterate(func(index int) bool {
+		indices = append(indices, index)
+		return index < 64
+	})
+	assert.Equal(t, []int{0, 63, 64}, indices)
+
+	next, _ := set.NextSetBit(65)
+	assert.Equal(t, 1000, next)
+	previous, _ := set.PreviousSetBit(999)
+	assert.Equal(t, 64, previous)
+	next, _ = set.NextClearBit(63)
+	assert.Equal(t, 65, next)
+	previous, _ = set.PreviousClearBit(64)
+	assert.Equal(t, 62, previous)
+}
+
+func TestCloneWithSize(t *testing.T) {
+	set, _ := FromIndices([]int{3, 70, 200})
+
+	wide := set.CloneWithSize(1000)
+	assert.Equal(t, 1024, wide.Size())
+	assert.True(t, set.Equal(wide))
+
+	other, _ := FromIndices([]int{900})
+	assert.Equal(t, "{3, 70, 200, 900}", wide.Or(other).String())
+	assert.Equal(t, "{3, 70, 200}", set.String())
+
+	narrow := set.CloneWithSize(100)
+	assert.Equal(t, 128, narrow.Size())
+	assert.Equal(t, "{3, 70}", narrow.String())
+	assert.Equal(t, minBits, set.CloneWithSize(0).Size())
+
+	bounded, _ := NewSet(WithFixedSize(100))
+	bounded.Set(99)
+	assert.Equal(t, 128, bounded.CloneWithSize(1000).Size())
+	assert.Equal(t, 100, bounded.CloneWithSize(1000).MaxBits())
+
+	strict, _ := NewSet(WithStrictMode())
+	assert.Panics(t, func() { strict.CloneWithSize(-1) })
+}
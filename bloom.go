@@ -0,0 +1,222 @@
+package bit
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+)
+
+// BloomFilter is a space-efficient probabilistic data structure backed by a
+// fixed-size Set. It reports whether an element is possibly in the filter or
+// definitely not in it.
+type BloomFilter struct {
+	m   int // number of bits
+	k   int // number of hash functions
+	set *Set
+}
+
+// NewBloomFilter creates a Bloom filter with m bits and k hash functions.
+func NewBloomFilter(m int, k int) (*BloomFilter, error) {
+	if m <= 0 {
+		return nil, fmt.Errorf("%w: Number of bits should be positive", ErrInvalidArgument)
+	}
+	if k <= 0 {
+		return nil, fmt.Errorf("%w: Number of hash functions should be positive", ErrInvalidArgument)
+	}
+
+	set, err := NewSet(WithInitialBits(m))
+	if err != nil {
+		return nil, err
+	}
+
+	return &BloomFilter{
+		m:   m,
+		k:   k,
+		set: set,
+	}, nil
+}
+
+// NewBloomFilterWithEstimates creates a Bloom filter sized for n elements
+// with the given false positive rate.
+func NewBloomFilterWithEstimates(n int, fpRate float64) (*BloomFilter, error) {
+	m, k := EstimateBloomParameters(n, fpRate)
+	return NewBloomFilter(m, k)
+}
+
+// EstimateBloomParameters returns the number of bits (m) and hash functions (k)
+// needed for holding n elements with the given false positive rate.
+func EstimateBloomParameters(n int, fpRate float64) (m int, k int) {
+	if n <= 0 {
+		n = 1
+	}
+	if fpRate <= 0 || fpRate >= 1 {
+		fpRate = 0.01
+	}
+
+	m = int(math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2)))
+	k = int(math.Ceil(math.Ln2 * float64(m) / float64(n)))
+	if k < 1 {
+		k = 1
+	}
+	return
+}
+
+// Cap returns the number of bits of the filter.
+func (f *BloomFilter) Cap() int {
+	return f.m
+}
+
+// K returns the number of hash functions used by the filter.
+func (f *BloomFilter) K() int {
+	return f.k
+}
+
+// BitSet returns the underlying bit set.
+func (f *BloomFilter) BitSet() *Set {
+	return f.set
+}
+
+// Add adds the data to the filter.
+func (f *BloomFilter) Add(data []byte) *BloomFilter {
+	h1, h2 := hash128(data)
+	for i := 0; i < f.k; i++ {
+		f.set.Set(bloomLocation(h1, h2, i, f.m))
+	}
+	return f
+}
+
+// Test returns true if the data is possibly in the filter, and false if
+// it is definitely not.
+func (f *BloomFilter) Test(data []byte) bool {
+	h1, h2 := hash128(data)
+	for i := 0; i < f.k; i++ {
+		if !f.set.Get(bloomLocation(h1, h2, i, f.m)) {
+			return false
+		}
+	}
+	return true
+}
+
+// TestAndAdd reports whether the data was possibly in the filter
+// (same as Test) and then adds it to the filter.
+func (f *BloomFilter) TestAndAdd(data []byte) bool {
+	h1, h2 := hash128(data)
+	present := true
+	for i := 0; i < f.k; i++ {
+		location := bloomLocation(h1, h2, i, f.m)
+		if !f.set.Get(location) {
+			present = false
+			f.set.Set(location)
+		}
+	}
+	return present
+}
+
+// ClearAll removes all the elements from the filter.
+func (f *BloomFilter) ClearAll() *BloomFilter {
+	f.set.ClearAll()
+	return f
+}
+
+// Union merges the other filter into this filter, so it contains the
+// elements of both. Both filters must have the same parameters.
+func (f *BloomFilter) Union(other *BloomFilter) error {
+	if !f.compatible(other) {
+		return fmt.Errorf("%w: Bloom filters have different parameters", ErrInvalidArgument)
+	}
+
+	f.set.Or(other.set)
+	return nil
+}
+
+// Intersect keeps only the bits that are set in both this filter and the
+// other filter. Both filters must have the same parameters.
+func (f *BloomFilter) Intersect(other *BloomFilter) error {
+	if !f.compatible(other) {
+		return fmt.Errorf("%w: Bloom filters have different parameters", ErrInvalidArgument)
+	}
+
+	f.set.And(other.set)
+	return nil
+}
+
+// ApproximatedSize returns the approximate number of elements added to the
+// filter, based on the number of set bits.
+func (f *BloomFilter) ApproximatedSize() int {
+	x := float64(f.set.Cardinality())
+	m := float64(f.m)
+	if x >= m {
+		// filter is saturated
+		return math.MaxInt32
+	}
+
+	return int(math.Round(-m / float64(f.k) * math.Log(1-x/m)))
+}
+
+// Clone creates a new copy of the filter
+func (f *BloomFilter) Clone() *BloomFilter {
+	return &BloomFilter{
+		m:   f.m,
+		k:   f.k,
+		set: f.set.Clone(),
+	}
+}
+
+// Equal checks whether both filters have the same parameters and bits.
+func (f *BloomFilter) Equal(other *BloomFilter) bool {
+	return f.compatible(other) && f.set.Equal(other.set)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The format is m and k as little endian uint64, followed by Bytes() of
+// the underlying set.
+func (f *BloomFilter) MarshalBinary() ([]byte, error) {
+	header := make([]byte, 16)
+	binary.LittleEndian.PutUint64(header[0:8], uint64(f.m))
+	binary.LittleEndian.PutUint64(header[8:16], uint64(f.k))
+
+	return append(header, f.set.Bytes()...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (f *BloomFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < 16 {
+		return fmt.Errorf("%w: Bloom filter data is too short", ErrCorruptData)
+	}
+
+	m := int(binary.LittleEndian.Uint64(data[0:8]))
+	k := int(binary.LittleEndian.Uint64(data[8:16]))
+	if m <= 0 || k <= 0 {
+		return fmt.Errorf("%w: Bloom filter data", ErrCorruptData)
+	}
+
+	payload := data[16:]
+	if len(payload) != howManyUint64(m)*8 {
+		return fmt.Errorf("%w: Bloom filter data", ErrCorruptData)
+	}
+
+	f.m = m
+	f.k = k
+	f.set = FromByteArray(payload)
+	return nil
+}
+
+func (f *BloomFilter) compatible(other *BloomFilter) bool {
+	return f.m == other.m && f.k == other.k
+}
+
+// bloomLocation uses double hashing (Kirsch-Mitzenmacher) to derive
+// the i-th location within m slots from two hash values.
+func bloomLocation(h1, h2 uint64, i int, m int) int {
+	return int((h1 + uint64(i)*h2) % uint64(m))
+}
+
+// hash128 returns the upper and lower 64 bits of the 128-bit FNV-1a hash
+func hash128(data []byte) (uint64, uint64) {
+	h := fnv.New128a()
+	h.Write(data)
+	sum := h.Sum(nil)
+
+	return binary.BigEndian.Uint64(sum[0:8]), binary.BigEndian.Uint64(sum[8:16])
+}
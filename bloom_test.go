@@ -0,0 +1,125 @@
+package bit
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBloomFilter(t *testing.T) {
+	testCases := []struct {
+		m        int
+		k        int
+		hasError bool
+	}{
+		{m: 1024, k: 3, hasError: false},
+		{m: 1, k: 1, hasError: false},
+		{m: 0, k: 3, hasError: true},
+		{m: -5, k: 3, hasError: true},
+		{m: 1024, k: 0, hasError: true},
+	}
+
+	for _, test := range testCases {
+		f, err := NewBloomFilter(test.m, test.k)
+		if test.hasError {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, test.m, f.Cap())
+		assert.Equal(t, test.k, f.K())
+	}
+}
+
+func TestEstimateBloomParameters(t *testing.T) {
+	m, k := EstimateBloomParameters(1000, 0.01)
+	assert.Equal(t, 9586, m)
+	assert.Equal(t, 7, k)
+}
+
+func TestBloomFilterAddAndTest(t *testing.T) {
+	f, err := NewBloomFilterWithEstimates(1000, 0.01)
+	if err != nil {
+		t.FailNow()
+	}
+
+	for i := 0; i < 1000; i++ {
+		f.Add([]byte(strconv.Itoa(i)))
+	}
+
+	// no false negatives
+	for i := 0; i < 1000; i++ {
+		assert.True(t, f.Test([]byte(strconv.Itoa(i))))
+	}
+
+	falsePositives := 0
+	for i := 1000; i < 11000; i++ {
+		if f.Test([]byte(strconv.Itoa(i))) {
+			falsePositives++
+		}
+	}
+	assert.Less(t, float64(falsePositives)/10000, 0.02)
+}
+
+func TestBloomFilterTestAndAdd(t *testing.T) {
+	f, err := NewBloomFilter(1024, 3)
+	if err != nil {
+		t.FailNow()
+	}
+
+	assert.False(t, f.TestAndAdd([]byte("hello")))
+	assert.True(t, f.TestAndAdd([]byte("hello")))
+	assert.True(t, f.Test([]byte("hello")))
+}
+
+func TestBloomFilterUnionAndIntersect(t *testing.T) {
+	f1, _ := NewBloomFilter(1024, 3)
+	f2, _ := NewBloomFilter(1024, 3)
+	f3, _ := NewBloomFilter(2048, 3)
+
+	f1.Add([]byte("a")).Add([]byte("common"))
+	f2.Add([]byte("b")).Add([]byte("common"))
+
+	assert.Error(t, f1.Union(f3))
+	assert.Error(t, f1.Intersect(f3))
+
+	union := f1.Clone()
+	assert.NoError(t, union.Union(f2))
+	assert.True(t, union.Test([]byte("a")))
+	assert.True(t, union.Test([]byte("b")))
+	assert.True(t, union.Test([]byte("common")))
+
+	intersect := f1.Clone()
+	assert.NoError(t, intersect.Intersect(f2))
+	assert.True(t, intersect.Test([]byte("common")))
+}
+
+func TestBloomFilterApproximatedSize(t *testing.T) {
+	f, _ := NewBloomFilterWithEstimates(10000, 0.01)
+	assert.Equal(t, 0, f.ApproximatedSize())
+
+	for i := 0; i < 5000; i++ {
+		f.Add([]byte(strconv.Itoa(i)))
+	}
+
+	size := f.ApproximatedSize()
+	assert.InDelta(t, 5000, size, 150)
+}
+
+func TestBloomFilterMarshalBinary(t *testing.T) {
+	f, _ := NewBloomFilter(100, 4)
+	f.Add([]byte("a")).Add([]byte("b"))
+
+	data, err := f.MarshalBinary()
+	assert.NoError(t, err)
+
+	decoded := &BloomFilter{}
+	assert.NoError(t, decoded.UnmarshalBinary(data))
+	assert.True(t, f.Equal(decoded))
+	assert.True(t, decoded.Test([]byte("a")))
+	assert.True(t, decoded.Test([]byte("b")))
+
+	assert.ErrorIs(t, decoded.UnmarshalBinary(data[:10]), ErrCorruptData)
+	assert.ErrorIs(t, decoded.UnmarshalBinary(data[:20]), ErrCorruptData)
+}
@@ -0,0 +1,75 @@
+package bit
+
+import (
+	"fmt"
+)
+
+// Cap returns the number of bits the set can hold without allocating,
+// which is at least Size().
+func (set *Set) Cap() int {
+	return cap(set.arr) * minBits
+}
+
+// Trim drops the trailing zero words, so Size() becomes Length() rounded up to
+// a multiple of 64, but at least 64. It never drops below the bits reserved by
+// WithInitialBits, so Or and Xor, which only combine the common words, behave
+// the same before and after. The memory stays reserved, see Compact.
+func (set *Set) Trim() *Set {
+	set.invalidate()
+	set.arr = set.arr[:set.trimmedLen()]
+	return set
+}
+
+// Compact drops the trailing zero words like Trim, down to the bits reserved
+// by WithInitialBits, and reallocates the set to release the memory which is
+// not needed anymore.
+func (set *Set) Compact() *Set {
+	set.invalidate()
+	arr := make([]uint64, set.trimmedLen())
+	copy(arr, set.arr)
+	set.arr = arr
+	return set
+}
+
+// Grow reserves memory for at least nbits bits, so the set grows up to nbits
+// without allocating. It doesn't change Size(), and never reserves past the
+// bound of the set. If nbits is negative no change will happen, or it panics
+// in strict mode.
+func (set *Set) Grow(nbits int) *Set {
+	if nbits < 0 {
+		set.misuse(fmt.Errorf("%w: Number of bits is negative: %d", ErrInvalidArgument, nbits))
+		return set
+	}
+	if set.maxBits > 0 {
+		nbits = min(nbits, set.maxBits)
+	}
+
+	if words := howManyUint64(nbits); words > cap(set.arr) {
+		arr := make([]uint64, len(set.arr), words)
+		copy(arr, set.arr)
+		set.arr = arr
+	}
+	return set
+}
+
+// shrinkIfNeeded compacts the set when the reserved memory past the last
+// non-zero word reaches the auto-shrink threshold
+func (set *Set) shrinkIfNeeded() {
+	if set.shrinkBits == 0 {
+		return
+	}
+
+	if (cap(set.arr)-set.trimmedLen())*minBits >= set.shrinkBits {
+		set.Compact()
+	}
+}
+
+// trimmedLen returns the number of words up to the last non-zero word,
+// but at least one and never less than the words reserved on creation
+func (set *Set) trimmedLen() int {
+	n := len(set.arr)
+	for n > max(1, set.minWords) && set.arr[n-1] == 0 {
+		n--
+	}
+	return n
+}
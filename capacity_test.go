@@ -0,0 +1,122 @@
+package bit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrimAndCompact(t *testing.T) {
+	set, _ := NewSet()
+	set.Set(5).Set(1000)
+	assert.Equal(t, 1024, set.Size())
+
+	set.Clear(1000).Trim()
+	assert.Equal(t, minBits, set.Size())
+	assert.GreaterOrEqual(t, set.Cap(), 1024)
+	assert.Equal(t, "{5}", set.String())
+
+	set.Compact()
+	assert.Equal(t, minBits, set.Size())
+	assert.Equal(t, minBits, set.Cap())
+
+	set.Set(130).Compact()
+	assert.Equal(t, 3*minBits, set.Size())
+	assert.Equal(t, 3*minBits, set.Cap())
+
+	empty, _ := NewSet(WithInitialBits(500))
+	empty.Set(1000).Clear(1000).Compact()
+	assert.Equal(t, 512, empty.Size(), "the initial bits stay reserved")
+	assert.True(t, empty.IsEmpty())
+}
+
+func TestGrow(t *testing.T) {
+	set, _ := NewSet()
+	set.Set(3).Grow(1000)
+	assert.Equal(t, minBits, set.Size())
+	assert.Equal(t, 1024, set.Cap())
+	assert.Equal(t, "{3}", set.String())
+
+	set.Set(999)
+	assert.Equal(t, 1024, set.Size())
+	assert.Equal(t, 1024, set.Cap())
+
+	set.Grow(10)
+	assert.Equal(t, 1024, set.Cap())
+
+	bounded, _ := NewSet(WithMaxBits(100))
+	bounded.Grow(1000)
+	assert.Equal(t, 2*minBits, bounded.Cap())
+
+	strict, _ := NewSet(WithStrictMode())
+	assert.Panics(t, func() { strict.Grow(-1) })
+}
+
+func TestAutoShrink(t *testing.T) {
+	set, err := NewSet(WithAutoShrink(1024))
+	if err != nil {
+		t.FailNow()
+	}
+
+	set.Set(1).Set(200)
+	set.ClearRange(100, 300)
+	assert.Equal(t, 5*minBits, set.Size(), "less than the threshold is unused")
+
+	set.Set(5000).ClearRange(4000, 6000)
+	assert.Equal(t, minBits, set.Size())
+	assert.Equal(t, minBits, set.Cap())
+	assert.Equal(t, "{1}", set.String())
+
+	set.Set(5000).ClearAll()
+	assert.Equal(t, minBits, set.Cap())
+
+	other, _ := NewSet(WithInitialBits(6000))
+	set.Set(1).Set(5000).And(other)
+	assert.Equal(t, minBits, set.Cap())
+
+	set.Set(5000).ShiftRight(4990)
+	assert.Equal(t, minBits, set.Cap())
+	assert.Equal(t, "{10}", set.String())
+
+	set.Set(5000).DeleteRange(100, 5001)
+	assert.Equal(t, minBits, set.Cap())
+
+	clone := set.Set(5000).Clone()
+	clone.ClearAll()
+	assert.Equal(t, minBits, clone.Cap())
+
+	_, err = NewSet(WithAutoShrink(-1))
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestShrinkKeepsReservedBits(t *testing.T) {
+	other, _ := NewSet()
+	other.Set(500)
+
+	for _, shrink := range []func(set *Set){
+		func(set *Set) {},
+		func(set *Set) { set.Trim() },
+		func(set *Set) { set.Compact() },
+		func(set *Set) { set.ClearAll() },
+	} {
+		set, _ := NewSet(WithInitialBits(1024), WithAutoShrink(256))
+		shrink(set)
+		assert.Equal(t, 1024, set.Size())
+		assert.Equal(t, "{500}", set.Clone().Or(other).String())
+		assert.Equal(t, "{500}", set.Xor(other).String())
+	}
+
+	set, _ := NewSet(WithInitialBits(1024))
+	set.Set(5000).Clear(5000).Compact()
+	assert.Equal(t, 1024, set.Cap())
+
+	bounded, _ := NewSet(WithFixedSize(100))
+	other.Set(70).Set(99).Set(100)
+	bounded.Or(other)
+	assert.Equal(t, "{70, 99}", bounded.String())
+
+	// only the common words are combined
+	small, _ := NewSet()
+	small.Or(other)
+	assert.True(t, small.IsEmpty())
+}
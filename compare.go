@@ -0,0 +1,116 @@
+package bit
+
+import (
+	"sort"
+)
+
+// Ordering determines how sets are ordered by Compare.
+// Both orderings are total orders consistent with Equal.
+type Ordering int
+
+const (
+	// NumericOrder orders the sets as unsigned integers whose bit i is the bit
+	// at index i, so the highest differing bit decides.
+	NumericOrder Ordering = iota
+	// LexicographicOrder orders the sets by the lexicographic order of their
+	// sorted lists of indices, like {1, 3} < {1, 3, 4} < {1, 5} < {2}.
+	LexicographicOrder
+)
+
+// Compare compares the sets in NumericOrder. It returns -1 if a is less
+// than b, 0 if they are Equal and +1 if a is greater than b.
+func Compare(a, b *Set) int {
+	return NumericOrder.Compare(a, b)
+}
+
+// Compare compares the sets in this ordering. It returns -1 if a is less
+// than b, 0 if they are Equal and +1 if a is greater than b.
+// It can be passed to slices.SortFunc.
+func (o Ordering) Compare(a, b *Set) int {
+	if o == LexicographicOrder {
+		return compareLexicographic(a, b)
+	}
+
+	return compareNumeric(a, b)
+}
+
+// Less checks whether a is less than b in this ordering.
+// It can be used with sort.Slice.
+func (o Ordering) Less(a, b *Set) bool {
+	return o.Compare(a, b) < 0
+}
+
+// Sort sorts the sets in this ordering.
+func (o Ordering) Sort(sets []*Set) {
+	sort.Slice(sets, func(i, j int) bool {
+		return o.Less(sets[i], sets[j])
+	})
+}
+
+func compareNumeric(a, b *Set) int {
+	for i := max(len(a.arr), len(b.arr)) - 1; i >= 0; i-- {
+		wa, wb := wordAt(a, i), wordAt(b, i)
+		if wa != wb {
+			return compareUint64(wa, wb)
+		}
+	}
+
+	return 0
+}
+
+func compareLexicographic(a, b *Set) int {
+	for i := 0; i < max(len(a.arr), len(b.arr)); i++ {
+		wa, wb := wordAt(a, i), wordAt(b, i)
+		if wa == wb {
+			continue
+		}
+
+		// both lists are the same up to the lowest differing bit, which is in
+		// the list of one set only. That set is less if the list of the other
+		// set goes on with a higher index, or greater if it ends there.
+		low := (wa ^ wb) & -(wa ^ wb)
+		if wa&low != 0 {
+			if hasBitAbove(b, i, low) {
+				return -1
+			}
+			return 1
+		}
+
+		if hasBitAbove(a, i, low) {
+			return 1
+		}
+		return -1
+	}
+
+	return 0
+}
+
+// hasBitAbove checks whether the set has a set bit above the single bit
+// of the i-th word
+func hasBitAbove(set *Set, i int, bit uint64) bool {
+	if wordAt(set, i)&^(bit|(bit-1)) != 0 {
+		return true
+	}
+
+	for _, w := range set.arr[min(i+1, len(set.arr)):] {
+		if w != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func compareUint64(a, b uint64) int {
+	if a < b {
+		return -1
+	}
+	return 1
+}
+
+// wordAt returns the i-th word of the set, words past the end are zero
+func wordAt(set *Set, i int) uint64 {
+	if i < len(set.arr) {
+		return set.arr[i]
+	}
+	return 0
+}
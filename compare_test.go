@@ -0,0 +1,90 @@
+package bit
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	testCases := []struct {
+		a, b          []int
+		numeric       int
+		lexicographic int
+	}{
+		{nil, nil, 0, 0},
+		{[]int{1, 3}, []int{1, 3}, 0, 0},
+		{nil, []int{0}, -1, -1},
+		{[]int{1, 3}, []int{1, 3, 4}, -1, -1},
+		{[]int{1, 3, 4}, []int{1, 5}, -1, -1},
+		{[]int{1, 5}, []int{2}, 1, -1},
+		{[]int{2}, []int{1, 5}, -1, 1},
+		{[]int{0, 200}, []int{0, 100}, 1, 1},
+		{[]int{0, 100}, []int{0, 100, 200}, -1, -1},
+		{[]int{64}, []int{63, 64}, -1, 1},
+	}
+
+	for _, tc := range testCases {
+		a, _ := FromIndices(tc.a)
+		b, _ := FromIndices(tc.b)
+		assert.Equal(t, tc.numeric, Compare(a, b), "%v %v", tc.a, tc.b)
+		assert.Equal(t, tc.numeric, NumericOrder.Compare(a, b), "%v %v", tc.a, tc.b)
+		assert.Equal(t, tc.lexicographic, LexicographicOrder.Compare(a, b), "%v %v", tc.a, tc.b)
+		assert.Equal(t, -tc.lexicographic, LexicographicOrder.Compare(b, a), "%v %v", tc.a, tc.b)
+	}
+}
+
+func TestCompareConsistentWithEqual(t *testing.T) {
+	small, _ := NewSet()
+	large, _ := NewSet(WithInitialBits(1000))
+	small.Set(7)
+	large.Set(7)
+
+	assert.Equal(t, 0, NumericOrder.Compare(small, large))
+	assert.Equal(t, 0, LexicographicOrder.Compare(large, small))
+}
+
+func TestCompareRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	lists := make([][]int, 200)
+	sets := make([]*Set, len(lists))
+	for i := range lists {
+		for index := 0; index < 150; index++ {
+			if r.Intn(20) == 0 {
+				lists[i] = append(lists[i], index)
+			}
+		}
+		sets[i], _ = FromIndices(lists[i])
+	}
+
+	for i := range sets {
+		for j := range sets {
+			assert.Equal(t, compareLists(lists[i], lists[j]), LexicographicOrder.Compare(sets[i], sets[j]))
+		}
+	}
+
+	NumericOrder.Sort(sets)
+	for i := 1; i < len(sets); i++ {
+		assert.LessOrEqual(t, sets[i-1].ToBigInt().Cmp(sets[i].ToBigInt()), 0)
+	}
+}
+
+func compareLists(a, b []int) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return 0
+}
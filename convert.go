@@ -0,0 +1,98 @@
+package bit
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// number of big.Word in a word of the set
+const bigWordsPerWord = minBits / bits.UintSize
+
+// FromBigInt returns a new bit set of the binary representation of the
+// number, where bit i of the number is stored at index i.
+// An error is returned if the number is negative.
+func FromBigInt(x *big.Int) (*Set, error) {
+	if x.Sign() < 0 {
+		return nil, fmt.Errorf("%w: Number is negative", ErrInvalidArgument)
+	}
+
+	words := x.Bits()
+	set, _ := NewSet(WithInitialBits(len(words) * bits.UintSize))
+	for i, word := range words {
+		shift := uint(i%bigWordsPerWord) * bits.UintSize
+		set.arr[i/bigWordsPerWord] |= uint64(word) << shift
+	}
+
+	return set, nil
+}
+
+// ToBigInt returns a new number whose binary representation is the bit set,
+// where the bit at index i is bit i of the number.
+func (set *Set) ToBigInt() *big.Int {
+	words := make([]big.Word, len(set.arr)*bigWordsPerWord)
+	for i := range words {
+		shift := uint(i%bigWordsPerWord) * bits.UintSize
+		words[i] = big.Word(set.arr[i/bigWordsPerWord] >> shift)
+	}
+
+	return new(big.Int).SetBits(words)
+}
+
+// FromBools returns a new bit set where the bit at index i is values[i].
+func FromBools(values []bool) *Set {
+	set, _ := NewSet(WithInitialBits(len(values)))
+	for i, value := range values {
+		if value {
+			set.arr[i/minBits] |= 1 << uint(i%minBits)
+		}
+	}
+
+	return set
+}
+
+// ToBools returns the bits of the set up to the highest set bit, so its
+// length is Length() and the last value is true unless the set is empty.
+func (set *Set) ToBools() []bool {
+	values := make([]bool, set.Length())
+	set.Iterate(func(index int) bool {
+		values[index] = true
+		return true
+	})
+
+	return values
+}
+
+// FromIndices returns a new bit set where the bits at the indices are set
+// to true. An error is returned if an index is negative.
+func FromIndices(indices []int) (*Set, error) {
+	length := 0
+	for _, index := range indices {
+		if index < 0 {
+			return nil, fmt.Errorf("%w: %d", ErrNegativeIndex, index)
+		}
+		if index >= length {
+			length = index + 1
+		}
+	}
+
+	set, _ := NewSet(WithInitialBits(length))
+	for _, index := range indices {
+		set.arr[index/minBits] |= 1 << uint(index%minBits)
+	}
+
+	return set, nil
+}
+
+// ToIndices returns the indices of the set bits in ascending order. They are
+// appended to buf[:0], so a buffer with enough capacity is filled without
+// any allocation, and nil can be passed to allocate a new one.
+func (set *Set) ToIndices(buf []int) []int {
+	buf = buf[:0]
+	set.Iterate(func(index int) bool {
+		buf = append(buf, index)
+		return true
+	})
+
+	return buf
+}
@@ -0,0 +1,66 @@
+package bit
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBigInt(t *testing.T) {
+	x, _ := new(big.Int).SetString("1208925819614629174706177", 10) // 2^80 + 1
+	set, err := FromBigInt(x)
+	assert.NoError(t, err)
+	assert.Equal(t, "{0, 80}", set.String())
+	assert.Equal(t, 0, x.Cmp(set.ToBigInt()))
+
+	set.Set(200).Clear(0)
+	expected := new(big.Int).Lsh(big.NewInt(1), 200)
+	expected.SetBit(expected, 80, 1)
+	assert.Equal(t, 0, expected.Cmp(set.ToBigInt()))
+
+	zero, err := FromBigInt(new(big.Int))
+	assert.NoError(t, err)
+	assert.True(t, zero.IsEmpty())
+	assert.Equal(t, 0, zero.ToBigInt().Sign())
+
+	_, err = FromBigInt(big.NewInt(-1))
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestBools(t *testing.T) {
+	values := make([]bool, 130)
+	values[1] = true
+	values[64] = true
+	values[129] = true
+
+	set := FromBools(values)
+	assert.Equal(t, "{1, 64, 129}", set.String())
+	assert.Equal(t, values, set.ToBools())
+
+	set.Clear(129)
+	assert.Equal(t, values[:65], set.ToBools())
+
+	assert.True(t, FromBools(nil).IsEmpty())
+	assert.Equal(t, []bool{}, FromBools([]bool{false, false}).ToBools())
+}
+
+func TestIndices(t *testing.T) {
+	set, err := FromIndices([]int{300, 0, 63, 64, 63})
+	assert.NoError(t, err)
+	assert.Equal(t, "{0, 63, 64, 300}", set.String())
+	assert.Equal(t, 300/minBits+1, len(set.arr))
+
+	buf := make([]int, 2, 10)
+	indices := set.ToIndices(buf)
+	assert.Equal(t, []int{0, 63, 64, 300}, indices)
+	assert.Equal(t, &buf[:1][0], &indices[0])
+	assert.Equal(t, []int{0, 63, 64, 300}, set.ToIndices(nil))
+
+	empty, err := FromIndices(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{}, empty.ToIndices(make([]int, 0)))
+
+	_, err = FromIndices([]int{1, -1})
+	assert.ErrorIs(t, err, ErrNegativeIndex)
+}
@@ -0,0 +1,215 @@
+package bit
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	// number of bits of each counter in CountingBloomFilter
+	counterBits = 4
+	// number of counters packed in each uint64
+	countersPerWord = minBits / counterBits
+	// maximum value of a counter, a saturated counter never changes
+	counterMax = 1<<counterBits - 1
+)
+
+// CountingBloomFilter is a Bloom filter that supports removal of elements.
+// Instead of a single bit, every location holds a 4-bit counter and 16
+// counters are packed in each uint64 word.
+// A counter that reaches 15 is saturated and will never be decremented,
+// so removing elements never introduces false negatives.
+type CountingBloomFilter struct {
+	m   int // number of counters
+	k   int // number of hash functions
+	arr []uint64
+}
+
+// NewCountingBloomFilter creates a counting Bloom filter with m counters
+// and k hash functions.
+func NewCountingBloomFilter(m int, k int) (*CountingBloomFilter, error) {
+	if m <= 0 {
+		return nil, fmt.Errorf("%w: Number of counters should be positive", ErrInvalidArgument)
+	}
+	if k <= 0 {
+		return nil, fmt.Errorf("%w: Number of hash functions should be positive", ErrInvalidArgument)
+	}
+
+	return &CountingBloomFilter{
+		m:   m,
+		k:   k,
+		arr: make([]uint64, howManyCounterWords(m)),
+	}, nil
+}
+
+// NewCountingBloomFilterWithEstimates creates a counting Bloom filter sized
+// for n elements with the given false positive rate.
+func NewCountingBloomFilterWithEstimates(n int, fpRate float64) (*CountingBloomFilter, error) {
+	m, k := EstimateBloomParameters(n, fpRate)
+	return NewCountingBloomFilter(m, k)
+}
+
+// Cap returns the number of counters of the filter.
+func (f *CountingBloomFilter) Cap() int {
+	return f.m
+}
+
+// K returns the number of hash functions used by the filter.
+func (f *CountingBloomFilter) K() int {
+	return f.k
+}
+
+// Add adds the data to the filter.
+func (f *CountingBloomFilter) Add(data []byte) *CountingBloomFilter {
+	h1, h2 := hash128(data)
+	for i := 0; i < f.k; i++ {
+		f.increment(bloomLocation(h1, h2, i, f.m))
+	}
+	return f
+}
+
+// Remove removes the data from the filter. It returns false if the data
+// is definitely not in the filter, in which case nothing changes.
+func (f *CountingBloomFilter) Remove(data []byte) bool {
+	if !f.Test(data) {
+		return false
+	}
+
+	h1, h2 := hash128(data)
+	for i := 0; i < f.k; i++ {
+		f.decrement(bloomLocation(h1, h2, i, f.m))
+	}
+	return true
+}
+
+// Test returns true if the data is possibly in the filter, and false if
+// it is definitely not.
+func (f *CountingBloomFilter) Test(data []byte) bool {
+	h1, h2 := hash128(data)
+	for i := 0; i < f.k; i++ {
+		if f.counter(bloomLocation(h1, h2, i, f.m)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// TestAndAdd reports whether the data was possibly in the filter
+// (same as Test) and then adds it to the filter.
+func (f *CountingBloomFilter) TestAndAdd(data []byte) bool {
+	h1, h2 := hash128(data)
+	present := true
+	for i := 0; i < f.k; i++ {
+		location := bloomLocation(h1, h2, i, f.m)
+		if f.counter(location) == 0 {
+			present = false
+		}
+		f.increment(location)
+	}
+	return present
+}
+
+// ClearAll removes all the elements from the filter.
+func (f *CountingBloomFilter) ClearAll() *CountingBloomFilter {
+	for i := range f.arr {
+		f.arr[i] = 0
+	}
+	return f
+}
+
+// ToBloomFilter returns a regular Bloom filter with the same parameters,
+// in which every location with a non-zero counter is set.
+func (f *CountingBloomFilter) ToBloomFilter() *BloomFilter {
+	bf, _ := NewBloomFilter(f.m, f.k)
+	for i := 0; i < f.m; i++ {
+		if f.counter(i) > 0 {
+			bf.set.Set(i)
+		}
+	}
+	return bf
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The format is m and k as little endian uint64, followed by the counter
+// words as little endian uint64. Counter i is stored in word i/16 at bits
+// 4*(i%16) to 4*(i%16)+3.
+func (f *CountingBloomFilter) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 16+len(f.arr)*8)
+	binary.LittleEndian.PutUint64(data[0:8], uint64(f.m))
+	binary.LittleEndian.PutUint64(data[8:16], uint64(f.k))
+
+	for i, word := range f.arr {
+		binary.LittleEndian.PutUint64(data[16+i*8:], word)
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (f *CountingBloomFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < 16 {
+		return fmt.Errorf("%w: Counting Bloom filter data is too short", ErrCorruptData)
+	}
+
+	m := int(binary.LittleEndian.Uint64(data[0:8]))
+	k := int(binary.LittleEndian.Uint64(data[8:16]))
+	if m <= 0 || k <= 0 {
+		return fmt.Errorf("%w: Counting Bloom filter data", ErrCorruptData)
+	}
+
+	payload := data[16:]
+	if len(payload) != howManyCounterWords(m)*8 {
+		return fmt.Errorf("%w: Counting Bloom filter data", ErrCorruptData)
+	}
+
+	arr := make([]uint64, howManyCounterWords(m))
+	for i := range arr {
+		arr[i] = binary.LittleEndian.Uint64(payload[i*8:])
+	}
+
+	f.m = m
+	f.k = k
+	f.arr = arr
+	return nil
+}
+
+func (f *CountingBloomFilter) counter(index int) uint64 {
+	arrIndex, shift := locateCounter(index)
+	return (f.arr[arrIndex] >> shift) & counterMax
+}
+
+func (f *CountingBloomFilter) increment(index int) {
+	arrIndex, shift := locateCounter(index)
+	if (f.arr[arrIndex]>>shift)&counterMax == counterMax {
+		// saturated
+		return
+	}
+	f.arr[arrIndex] += 1 << shift
+}
+
+func (f *CountingBloomFilter) decrement(index int) {
+	arrIndex, shift := locateCounter(index)
+	c := (f.arr[arrIndex] >> shift) & counterMax
+	if c == 0 || c == counterMax {
+		// empty or saturated
+		return
+	}
+	f.arr[arrIndex] -= 1 << shift
+}
+
+// locateCounter finds the word holding the counter and the position of
+// its lowest bit within the word
+func locateCounter(index int) (arrIndex int, shift uint) {
+	arrIndex = index / countersPerWord
+	shift = uint(index%countersPerWord) * counterBits
+	return
+}
+
+// howManyCounterWords returns how many uint64 is needed for storing N counters
+func howManyCounterWords(ncounters int) int {
+	if ncounters <= 0 {
+		return 0
+	}
+
+	return (ncounters-1)/countersPerWord + 1
+}
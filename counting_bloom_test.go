@@ -0,0 +1,131 @@
+package bit
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountingBloomFilterAddRemove(t *testing.T) {
+	f, err := NewCountingBloomFilter(1024, 3)
+	if err != nil {
+		t.FailNow()
+	}
+
+	f.Add([]byte("a")).Add([]byte("b")).Add([]byte("b"))
+	assert.True(t, f.Test([]byte("a")))
+	assert.True(t, f.Test([]byte("b")))
+
+	assert.True(t, f.Remove([]byte("a")))
+	assert.False(t, f.Test([]byte("a")))
+	assert.False(t, f.Remove([]byte("a")))
+
+	// b has been added twice
+	assert.True(t, f.Remove([]byte("b")))
+	assert.True(t, f.Test([]byte("b")))
+	assert.True(t, f.Remove([]byte("b")))
+	assert.False(t, f.Test([]byte("b")))
+}
+
+func TestCountingBloomFilterSaturation(t *testing.T) {
+	f, _ := NewCountingBloomFilter(64, 2)
+
+	for i := 0; i < counterMax+5; i++ {
+		f.Add([]byte("a"))
+	}
+
+	// saturated counters are never decremented
+	for i := 0; i < counterMax+5; i++ {
+		assert.True(t, f.Remove([]byte("a")))
+	}
+	assert.True(t, f.Test([]byte("a")))
+}
+
+func TestCountingBloomFilterCounters(t *testing.T) {
+	f, _ := NewCountingBloomFilter(40, 1)
+
+	testCases := []struct {
+		index int
+		times int
+	}{
+		{index: 0, times: 1},
+		{index: 15, times: 3},
+		{index: 16, times: 7},
+		{index: 39, times: 20},
+	}
+
+	for _, test := range testCases {
+		for i := 0; i < test.times; i++ {
+			f.increment(test.index)
+		}
+	}
+
+	assert.Equal(t, 3, len(f.arr))
+	assert.Equal(t, uint64(1), f.counter(0))
+	assert.Equal(t, uint64(0), f.counter(1))
+	assert.Equal(t, uint64(3), f.counter(15))
+	assert.Equal(t, uint64(7), f.counter(16))
+	assert.Equal(t, uint64(counterMax), f.counter(39))
+}
+
+func TestCountingBloomFilterFalsePositiveRate(t *testing.T) {
+	n := 10000
+	fpRate := 0.01
+	f, _ := NewCountingBloomFilterWithEstimates(n, fpRate)
+	r := rand.New(rand.NewSource(42))
+
+	added := make(map[uint64]bool)
+	key := make([]byte, 8)
+	for len(added) < n {
+		v := r.Uint64()
+		added[v] = true
+		binary.LittleEndian.PutUint64(key, v)
+		f.Add(key)
+	}
+
+	falsePositives := 0
+	trials := 100000
+	for i := 0; i < trials; i++ {
+		v := r.Uint64()
+		if added[v] {
+			continue
+		}
+		binary.LittleEndian.PutUint64(key, v)
+		if f.Test(key) {
+			falsePositives++
+		}
+	}
+
+	assert.Less(t, float64(falsePositives)/float64(trials), fpRate*1.5)
+}
+
+func TestCountingBloomFilterToBloomFilter(t *testing.T) {
+	f, _ := NewCountingBloomFilter(512, 4)
+	f.Add([]byte("a")).Add([]byte("b"))
+
+	bf := f.ToBloomFilter()
+	assert.True(t, bf.Test([]byte("a")))
+	assert.True(t, bf.Test([]byte("b")))
+
+	expected, _ := NewBloomFilter(512, 4)
+	expected.Add([]byte("a")).Add([]byte("b"))
+	assert.True(t, expected.Equal(bf))
+}
+
+func TestCountingBloomFilterMarshalBinary(t *testing.T) {
+	f, _ := NewCountingBloomFilter(100, 3)
+	f.Add([]byte("a")).Add([]byte("a")).Add([]byte("b"))
+
+	data, err := f.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, 16+7*8, len(data))
+
+	decoded := &CountingBloomFilter{}
+	assert.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, f, decoded)
+
+	assert.ErrorIs(t, decoded.UnmarshalBinary(data[:8]), ErrCorruptData)
+	assert.ErrorIs(t, decoded.UnmarshalBinary(data[:24]), ErrCorruptData)
+}
@@ -0,0 +1,218 @@
+package bit
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// EliasFano is a compressed representation of a monotone (non-decreasing)
+// sequence of integers. Every number is split into low bits, which are
+// packed in a bit array, and high bits, which are unary coded in a Set
+// with rank and select support. It takes at most 2 + log(u/n) bits per
+// number, where u is the largest number plus one and n is the count.
+type EliasFano struct {
+	n        int    // number of values
+	universe uint64 // the largest value plus one
+	lowBits  int    // number of low bits of each value
+	low      *Set
+	high     *Set
+}
+
+// NewEliasFano creates an Elias-Fano sequence from the sorted values.
+// An error is returned if the values are not sorted in non-decreasing order,
+// or if a value is math.MaxUint64, as the universe is the largest value plus one.
+func NewEliasFano(values []uint64) (*EliasFano, error) {
+	for i := 1; i < len(values); i++ {
+		if values[i] < values[i-1] {
+			return nil, fmt.Errorf("%w: Values are not sorted", ErrInvalidArgument)
+		}
+	}
+	if len(values) > 0 && values[len(values)-1] == math.MaxUint64 {
+		return nil, fmt.Errorf("%w: Value is too large: %d", ErrInvalidArgument, values[len(values)-1])
+	}
+
+	universe := uint64(0)
+	if len(values) > 0 {
+		universe = values[len(values)-1] + 1
+	}
+
+	ef := newEliasFano(len(values), universe)
+	for i, value := range values {
+		ef.set(i, value)
+	}
+	ef.high.BuildRankSelect()
+
+	return ef, nil
+}
+
+// EliasFanoFromSet creates an Elias-Fano sequence from the indices of
+// the set bits of the set.
+func EliasFanoFromSet(set *Set) *EliasFano {
+	universe := uint64(set.Length())
+
+	ef := newEliasFano(set.Cardinality(), universe)
+	i := 0
+	set.Iterate(func(index int) bool {
+		ef.set(i, uint64(index))
+		i++
+		return true
+	})
+	ef.high.BuildRankSelect()
+
+	return ef
+}
+
+func newEliasFano(n int, universe uint64) *EliasFano {
+	lowBits := 0
+	if n > 0 && universe > uint64(n) {
+		lowBits = bits.Len64(universe/uint64(n)) - 1
+	}
+
+	highBits := n + 1
+	if universe > 0 {
+		highBits += int((universe - 1) >> uint(lowBits))
+	}
+
+	low, _ := NewSet(WithInitialBits(n * lowBits))
+	high, _ := NewSet(WithInitialBits(highBits))
+
+	return &EliasFano{
+		n:        n,
+		universe: universe,
+		lowBits:  lowBits,
+		low:      low,
+		high:     high,
+	}
+}
+
+// Len returns the number of values in the sequence.
+func (ef *EliasFano) Len() int {
+	return ef.n
+}
+
+// Get returns the i-th value of the sequence.
+// It panics if the index is out of range.
+func (ef *EliasFano) Get(i int) uint64 {
+	if i < 0 || i >= ef.n {
+		panic(fmt.Errorf("%w: Elias-Fano index %d", ErrOutOfBounds, i))
+	}
+
+	high := uint64(ef.high.Select1(i) - i)
+	return high<<uint(ef.lowBits) | readBits(ef.low.arr, i*ef.lowBits, ef.lowBits)
+}
+
+// NextGEQ returns the index and the value of the first value that is
+// greater than or equal to x. If there is no such value, ok is false.
+func (ef *EliasFano) NextGEQ(x uint64) (index int, value uint64, ok bool) {
+	if x >= ef.universe {
+		return ef.n, 0, false
+	}
+
+	// skip all the values with a smaller high part:
+	// the bucket of high part h starts right after the h-th zero
+	h := int(x >> uint(ef.lowBits))
+	position := 0
+	if h > 0 {
+		position = ef.high.Select0(h-1) + 1
+	}
+
+	for i := position - h; i < ef.n; i++ {
+		if value := ef.Get(i); value >= x {
+			return i, value, true
+		}
+	}
+
+	return ef.n, 0, false
+}
+
+// Iterate calls fn for every value of the sequence in order, until fn
+// returns false.
+func (ef *EliasFano) Iterate(fn func(index int, value uint64) bool) {
+	i := 0
+	for arrIndex, word := range ef.high.arr {
+		for word != 0 && i < ef.n {
+			position := arrIndex*minBits + bits.TrailingZeros64(word)
+			word &= word - 1
+
+			high := uint64(position - i)
+			value := high<<uint(ef.lowBits) | readBits(ef.low.arr, i*ef.lowBits, ef.lowBits)
+			if !fn(i, value) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Values returns all the values of the sequence.
+func (ef *EliasFano) Values() []uint64 {
+	values := make([]uint64, 0, ef.n)
+	ef.Iterate(func(_ int, value uint64) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The format is the number of values, the largest value plus one and the
+// number of low bits as little endian uint64, followed by the words of the
+// low bits and the words of the high bits as little endian uint64.
+// The number of words of each part can be computed from the header.
+func (ef *EliasFano) MarshalBinary() ([]byte, error) {
+	header := make([]byte, 24)
+	binary.LittleEndian.PutUint64(header[0:8], uint64(ef.n))
+	binary.LittleEndian.PutUint64(header[8:16], ef.universe)
+	binary.LittleEndian.PutUint64(header[16:24], uint64(ef.lowBits))
+
+	data := append(header, ef.low.Bytes()...)
+	return append(data, ef.high.Bytes()...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (ef *EliasFano) UnmarshalBinary(data []byte) error {
+	if len(data) < 24 {
+		return fmt.Errorf("%w: Elias-Fano data is too short", ErrCorruptData)
+	}
+
+	n := binary.LittleEndian.Uint64(data[0:8])
+	universe := binary.LittleEndian.Uint64(data[8:16])
+	lowBits := binary.LittleEndian.Uint64(data[16:24])
+	if n > uint64(len(data)) || lowBits >= minBits {
+		return fmt.Errorf("%w: Elias-Fano data", ErrCorruptData)
+	}
+	if universe > 0 && (universe-1)>>lowBits > uint64(len(data))*8 {
+		// high bits can't fit in the data
+		return fmt.Errorf("%w: Elias-Fano data", ErrCorruptData)
+	}
+
+	decoded := newEliasFano(int(n), universe)
+	if decoded.lowBits != int(lowBits) {
+		return fmt.Errorf("%w: Elias-Fano data", ErrCorruptData)
+	}
+
+	payload := data[24:]
+	lowBytes := len(decoded.low.arr) * 8
+	highBytes := len(decoded.high.arr) * 8
+	if len(payload) != lowBytes+highBytes {
+		return fmt.Errorf("%w: Elias-Fano data", ErrCorruptData)
+	}
+
+	decoded.low = FromByteArray(payload[:lowBytes])
+	decoded.high = FromByteArray(payload[lowBytes:])
+	if decoded.high.Cardinality() != decoded.n {
+		return fmt.Errorf("%w: Elias-Fano data", ErrCorruptData)
+	}
+	decoded.high.BuildRankSelect()
+
+	*ef = *decoded
+	return nil
+}
+
+// set stores the value at i-th position, values must be set in order
+func (ef *EliasFano) set(i int, value uint64) {
+	writeBits(ef.low.arr, i*ef.lowBits, ef.lowBits, value)
+	ef.high.Set(int(value>>uint(ef.lowBits)) + i)
+}
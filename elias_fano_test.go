@@ -0,0 +1,130 @@
+package bit
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEliasFano(t *testing.T) {
+	testCases := []struct {
+		values   []uint64
+		hasError bool
+	}{
+		{values: []uint64{}, hasError: false},
+		{values: []uint64{0}, hasError: false},
+		{values: []uint64{5, 5, 5}, hasError: false},
+		{values: []uint64{2, 3, 5, 7, 11, 13, 24}, hasError: false},
+		{values: []uint64{1, 1000000, 1 << 40}, hasError: false},
+		{values: []uint64{1, math.MaxUint64 - 1}, hasError: false},
+		{values: []uint64{3, 2}, hasError: true},
+		{values: []uint64{1, 5, math.MaxUint64}, hasError: true},
+	}
+
+	for _, test := range testCases {
+		ef, err := NewEliasFano(test.values)
+		if test.hasError {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, len(test.values), ef.Len())
+		for i, value := range test.values {
+			assert.Equal(t, value, ef.Get(i))
+		}
+		assert.Equal(t, test.values, ef.Values())
+	}
+}
+
+func TestEliasFanoFromSet(t *testing.T) {
+	set, _ := NewSet()
+	set.Set(3).Set(64).Set(65).Set(1000)
+
+	ef := EliasFanoFromSet(set)
+	assert.Equal(t, []uint64{3, 64, 65, 1000}, ef.Values())
+
+	empty, _ := NewSet()
+	assert.Equal(t, 0, EliasFanoFromSet(empty).Len())
+}
+
+func TestEliasFanoNextGEQ(t *testing.T) {
+	ef, _ := NewEliasFano([]uint64{2, 3, 5, 7, 11, 13, 24})
+
+	testCases := []struct {
+		x     uint64
+		index int
+		value uint64
+		ok    bool
+	}{
+		{x: 0, index: 0, value: 2, ok: true},
+		{x: 2, index: 0, value: 2, ok: true},
+		{x: 4, index: 2, value: 5, ok: true},
+		{x: 12, index: 5, value: 13, ok: true},
+		{x: 14, index: 6, value: 24, ok: true},
+		{x: 24, index: 6, value: 24, ok: true},
+		{x: 25, index: 7, value: 0, ok: false},
+	}
+
+	for _, test := range testCases {
+		index, value, ok := ef.NextGEQ(test.x)
+		assert.Equal(t, test.index, index)
+		assert.Equal(t, test.value, value)
+		assert.Equal(t, test.ok, ok)
+	}
+}
+
+func TestEliasFanoRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	values := make([]uint64, 1000)
+	for i := range values {
+		values[i] = uint64(r.Int63n(1 << 20))
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	ef, err := NewEliasFano(values)
+	assert.NoError(t, err)
+	assert.Equal(t, values, ef.Values())
+
+	for i := 0; i < 1000; i++ {
+		x := uint64(r.Int63n(1 << 20))
+		expected := sort.Search(len(values), func(i int) bool { return values[i] >= x })
+
+		index, value, ok := ef.NextGEQ(x)
+		assert.Equal(t, expected, index)
+		if expected < len(values) {
+			assert.True(t, ok)
+			assert.Equal(t, values[expected], value)
+		} else {
+			assert.False(t, ok)
+		}
+	}
+}
+
+func TestEliasFanoIterate(t *testing.T) {
+	ef, _ := NewEliasFano([]uint64{1, 4, 9, 16, 25})
+
+	visited := make([]uint64, 0)
+	ef.Iterate(func(index int, value uint64) bool {
+		visited = append(visited, value)
+		return index < 2
+	})
+	assert.Equal(t, []uint64{1, 4, 9}, visited)
+}
+
+func TestEliasFanoMarshalBinary(t *testing.T) {
+	ef, _ := NewEliasFano([]uint64{2, 3, 5, 7, 11, 13, 24, 1000})
+
+	data, err := ef.MarshalBinary()
+	assert.NoError(t, err)
+
+	decoded := &EliasFano{}
+	assert.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, ef.Values(), decoded.Values())
+	assert.Equal(t, uint64(13), decoded.Get(5))
+
+	assert.ErrorIs(t, decoded.UnmarshalBinary(data[:10]), ErrCorruptData)
+	assert.ErrorIs(t, decoded.UnmarshalBinary(data[:len(data)-1]), ErrCorruptData)
+}
@@ -0,0 +1,204 @@
+package bit
+
+import (
+	"bytes"
+	"fmt"
+	"math/bits"
+	"strconv"
+)
+
+// Enum is the set of integer types which can be stored in an EnumSet.
+type Enum interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// EnumSet is a set of values of an enum type E, stored as a Set where the bit
+// at index v is true if the value v is in the set. Values should be small
+// non-negative numbers, like the constants of a Go enum declared with iota.
+// Negative values and values which don't fit in int are never in the set.
+//
+// Sets of different enum types can't be mixed, so flags of one enum don't
+// leak into another.
+type EnumSet[E Enum] struct {
+	set *Set
+}
+
+// NewEnumSet creates a set of the values.
+func NewEnumSet[E Enum](values ...E) *EnumSet[E] {
+	set, _ := NewSet()
+	return (&EnumSet[E]{set: set}).Add(values...)
+}
+
+// Add adds the values to the set.
+func (s *EnumSet[E]) Add(values ...E) *EnumSet[E] {
+	for _, value := range values {
+		if index, ok := enumIndex(value); ok {
+			s.set.Set(index)
+		}
+	}
+	return s
+}
+
+// Remove removes the values from the set.
+func (s *EnumSet[E]) Remove(values ...E) *EnumSet[E] {
+	for _, value := range values {
+		if index, ok := enumIndex(value); ok {
+			s.set.Clear(index)
+		}
+	}
+	return s
+}
+
+// Has checks whether the value is in the set.
+func (s *EnumSet[E]) Has(value E) bool {
+	index, ok := enumIndex(value)
+	return ok && s.set.Get(index)
+}
+
+// HasAll checks whether all the values are in the set.
+func (s *EnumSet[E]) HasAll(values ...E) bool {
+	for _, value := range values {
+		if !s.Has(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasAny checks whether at least one of the values is in the set.
+func (s *EnumSet[E]) HasAny(values ...E) bool {
+	for _, value := range values {
+		if s.Has(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of values in the set.
+func (s *EnumSet[E]) Len() int {
+	return s.set.Cardinality()
+}
+
+// IsEmpty checks whether the set has no values.
+func (s *EnumSet[E]) IsEmpty() bool {
+	return s.set.IsEmpty()
+}
+
+// Clear removes all the values from the set.
+func (s *EnumSet[E]) Clear() *EnumSet[E] {
+	s.set.ClearAll()
+	return s
+}
+
+// Union returns a new set of the values which are in this set or in the other set.
+func (s *EnumSet[E]) Union(other *EnumSet[E]) *EnumSet[E] {
+	return &EnumSet[E]{set: s.set.CloneWithSize(max(s.set.Size(), other.set.Size())).Or(other.set)}
+}
+
+// Intersect returns a new set of the values which are in both sets.
+func (s *EnumSet[E]) Intersect(other *EnumSet[E]) *EnumSet[E] {
+	result := s.set.Clone().And(other.set)
+	// the words past the end of the other set are all clear
+	for i := len(other.set.arr); i < len(result.arr); i++ {
+		result.arr[i] = 0
+	}
+	return &EnumSet[E]{set: result}
+}
+
+// Difference returns a new set of the values which are in this set but not
+// in the other set.
+func (s *EnumSet[E]) Difference(other *EnumSet[E]) *EnumSet[E] {
+	result := s.set.Clone()
+	for i := 0; i < min(len(result.arr), len(other.set.arr)); i++ {
+		result.arr[i] &^= other.set.arr[i]
+	}
+	return &EnumSet[E]{set: result}
+}
+
+// SymmetricDifference returns a new set of the values which are in exactly
+// one of the sets.
+func (s *EnumSet[E]) SymmetricDifference(other *EnumSet[E]) *EnumSet[E] {
+	return &EnumSet[E]{set: s.set.CloneWithSize(max(s.set.Size(), other.set.Size())).Xor(other.set)}
+}
+
+// IsSubsetOf checks whether all the values of this set are in the other set.
+func (s *EnumSet[E]) IsSubsetOf(other *EnumSet[E]) bool {
+	return s.Difference(other).IsEmpty()
+}
+
+// Equal checks whether both sets have the same values.
+func (s *EnumSet[E]) Equal(other *EnumSet[E]) bool {
+	return s.set.Equal(other.set)
+}
+
+// Clone creates a new copy of the set.
+func (s *EnumSet[E]) Clone() *EnumSet[E] {
+	return &EnumSet[E]{set: s.set.Clone()}
+}
+
+// Iterate calls fn for every value of the set in ascending order, until fn
+// returns false.
+func (s *EnumSet[E]) Iterate(fn func(value E) bool) {
+	for arrIndex, word := range s.set.arr {
+		for word != 0 {
+			value := E(arrIndex*minBits + bits.TrailingZeros64(word))
+			word &= word - 1
+			if !fn(value) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns the values of the set in ascending order.
+func (s *EnumSet[E]) Values() []E {
+	values := make([]E, 0, s.Len())
+	s.Iterate(func(value E) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// BitSet returns the underlying set.
+func (s *EnumSet[E]) BitSet() *Set {
+	return s.set
+}
+
+// String returns the values of the set in ascending order, separated by ", "
+// and surrounded by braces. Values are formatted with their String method if
+// E implements fmt.Stringer, or as decimal numbers otherwise.
+func (s *EnumSet[E]) String() string {
+	b := bytes.Buffer{}
+	b.WriteByte('{')
+
+	first := true
+	s.Iterate(func(value E) bool {
+		if !first {
+			b.WriteString(", ")
+		}
+		first = false
+
+		if stringer, ok := any(value).(fmt.Stringer); ok {
+			b.WriteString(stringer.String())
+		} else {
+			b.WriteString(strconv.Itoa(int(value)))
+		}
+		return true
+	})
+
+	b.WriteByte('}')
+	return b.String()
+}
+
+// enumIndex returns the index of the value within the set, ok is false if
+// the value is negative or doesn't fit in int
+func enumIndex[E Enum](value E) (int, bool) {
+	index := int(value)
+	if value < 0 || index < 0 || E(index) != value {
+		return 0, false
+	}
+	return index, true
+}
@@ -0,0 +1,92 @@
+package bit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type permission uint8
+
+const (
+	read permission = iota
+	write
+	execute
+	admin permission = 100
+)
+
+func (p permission) String() string {
+	switch p {
+	case read:
+		return "read"
+	case write:
+		return "write"
+	case execute:
+		return "execute"
+	case admin:
+		return "admin"
+	}
+	return "unknown"
+}
+
+func TestEnumSet(t *testing.T) {
+	set := NewEnumSet(read, admin)
+	assert.True(t, set.Has(read))
+	assert.True(t, set.Has(admin))
+	assert.False(t, set.Has(write))
+	assert.True(t, set.HasAll(read, admin))
+	assert.False(t, set.HasAll(read, write))
+	assert.True(t, set.HasAny(write, admin))
+	assert.Equal(t, 2, set.Len())
+	assert.Equal(t, "{read, admin}", set.String())
+	assert.Equal(t, []permission{read, admin}, set.Values())
+
+	set.Add(write).Remove(admin)
+	assert.Equal(t, "{read, write}", set.String())
+
+	set.Clear()
+	assert.True(t, set.IsEmpty())
+	assert.Equal(t, "{}", set.String())
+}
+
+func TestEnumSetAlgebra(t *testing.T) {
+	a := NewEnumSet(read, write)
+	b := NewEnumSet(write, execute, admin)
+
+	assert.Equal(t, "{read, write, execute, admin}", a.Union(b).String())
+	assert.Equal(t, "{read, write, execute, admin}", b.Union(a).String())
+	assert.Equal(t, "{write}", a.Intersect(b).String())
+	assert.Equal(t, "{write}", b.Intersect(a).String())
+	assert.Equal(t, "{read}", a.Difference(b).String())
+	assert.Equal(t, "{execute, admin}", b.Difference(a).String())
+	assert.Equal(t, "{read, execute, admin}", a.SymmetricDifference(b).String())
+	assert.Equal(t, "{read, execute, admin}", b.SymmetricDifference(a).String())
+
+	// operands are not modified
+	assert.Equal(t, "{read, write}", a.String())
+	assert.Equal(t, "{write, execute, admin}", b.String())
+
+	assert.True(t, NewEnumSet(write).IsSubsetOf(a))
+	assert.False(t, b.IsSubsetOf(a))
+	assert.True(t, a.Equal(NewEnumSet(write, read)))
+	assert.True(t, a.Union(b).Difference(b).Equal(NewEnumSet(read)))
+}
+
+func TestEnumSetPlainValues(t *testing.T) {
+	type level int
+
+	set := NewEnumSet[level](3, 1, -1)
+	assert.Equal(t, "{1, 3}", set.String())
+	assert.False(t, set.Has(-1))
+
+	clone := set.Clone().Add(2)
+	assert.Equal(t, 2, set.Len())
+	assert.Equal(t, 3, clone.Len())
+
+	var visited []level
+	clone.Iterate(func(value level) bool {
+		visited = append(visited, value)
+		return value < 2
+	})
+	assert.Equal(t, []level{1, 2}, visited)
+}
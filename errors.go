@@ -0,0 +1,27 @@
+package bit
+
+import (
+	"errors"
+)
+
+// Errors returned, or used for panics, by this package. They are usually
+// wrapped with details, so they should be checked with errors.Is.
+var (
+	// ErrNegativeIndex is used when an index is negative
+	ErrNegativeIndex = errors.New("Index is negative")
+	// ErrInvalidRange is used when the end of a range is before its start
+	ErrInvalidRange = errors.New("Range is invalid")
+	// ErrOutOfBounds is used when an index is past the bound of a set
+	// or the length of a container
+	ErrOutOfBounds = errors.New("Index is out of bounds")
+	// ErrCorruptData is used when decoding data which is malformed
+	ErrCorruptData = errors.New("Data is corrupted")
+	// ErrInvalidArgument is used when an argument other than an index
+	// is invalid, such as a negative size
+	ErrInvalidArgument = errors.New("Argument is invalid")
+	// ErrUnknownFlag is used when a name is not defined in a FlagRegistry
+	ErrUnknownFlag = errors.New("Flag is not defined")
+	// ErrUnstableFlags is used when flag definitions change the meaning of
+	// bits of a previous version
+	ErrUnstableFlags = errors.New("Flag definitions are not stable")
+)
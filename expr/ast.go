@@ -0,0 +1,119 @@
+// Package expr implements a small boolean language over named bit sets.
+//
+// An expression combines names of sets with the operators ! (complement),
+// & (intersection), ^ (symmetric difference) and | (union), listed from the
+// highest to the lowest precedence, and parentheses. The constants 0 and 1
+// stand for the empty set and the universe. The function card(x) returns
+// the number of elements of a set expression.
+//
+//	(a & b) | !c ^ d
+//	card(premium & !churned)
+package expr
+
+import (
+	"strings"
+)
+
+// Op is a binary set operator.
+type Op int
+
+const (
+	// OpAnd is the intersection of sets
+	OpAnd Op = iota
+	// OpXor is the symmetric difference of sets
+	OpXor
+	// OpOr is the union of sets
+	OpOr
+)
+
+// String returns the symbol of the operator.
+func (op Op) String() string {
+	switch op {
+	case OpAnd:
+		return "&"
+	case OpXor:
+		return "^"
+	case OpOr:
+		return "|"
+	}
+	return "?"
+}
+
+// Node is a node of the abstract syntax tree of an expression.
+// String returns the canonical form of the node, two nodes with the same
+// canonical form always evaluate to the same value.
+type Node interface {
+	String() string
+	// IsNumber returns true if the node evaluates to a number
+	// instead of a set
+	IsNumber() bool
+}
+
+// Ident is a reference to a named set.
+type Ident struct {
+	Name string
+}
+
+// Const is the empty set or the universe.
+type Const struct {
+	Universe bool
+}
+
+// Not is the complement of a set within the universe.
+type Not struct {
+	X Node
+}
+
+// Binary applies an associative and commutative operator on two or more sets.
+type Binary struct {
+	Op       Op
+	Operands []Node
+}
+
+// Call is a function call.
+type Call struct {
+	Func string
+	Arg  Node
+}
+
+func (n *Ident) String() string {
+	return n.Name
+}
+
+func (n *Const) String() string {
+	if n.Universe {
+		return "1"
+	}
+	return "0"
+}
+
+func (n *Not) String() string {
+	return "!" + n.X.String()
+}
+
+func (n *Binary) String() string {
+	operands := make([]string, len(n.Operands))
+	for i, operand := range n.Operands {
+		operands[i] = operand.String()
+	}
+	return "(" + strings.Join(operands, " "+n.Op.String()+" ") + ")"
+}
+
+func (n *Call) String() string {
+	return n.Func + "(" + n.Arg.String() + ")"
+}
+
+// IsNumber implements Node.
+func (n *Ident) IsNumber() bool { return false }
+
+// IsNumber implements Node.
+func (n *Const) IsNumber() bool { return false }
+
+// IsNumber implements Node.
+func (n *Not) IsNumber() bool { return false }
+
+// IsNumber implements Node.
+func (n *Binary) IsNumber() bool { return false }
+
+// IsNumber implements Node.
+func (n *Call) IsNumber() bool { return true }
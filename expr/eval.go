@@ -0,0 +1,226 @@
+package expr
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mostafa-asg/bit"
+)
+
+// Evaluator evaluates expressions against a registry of named sets.
+// The complement of a set is taken within the universe, which holds the bits
+// from 0 to the universe size (exclusive), and bits of the registered sets
+// outside the universe are ignored.
+//
+// Results of subexpressions are cached by their canonical form and reused
+// by all the evaluations, so the registered sets should not be modified
+// while the evaluator is in use.
+type Evaluator struct {
+	sets     map[string]*bit.Set
+	universe *bit.Set
+	cache    map[string]*bit.Set
+}
+
+// NewEvaluator creates an evaluator over the named sets.
+func NewEvaluator(sets map[string]*bit.Set, universeSize int) (*Evaluator, error) {
+	if universeSize < 0 {
+		return nil, fmt.Errorf("%w: Universe size is negative", bit.ErrInvalidArgument)
+	}
+
+	universe, _ := bit.NewSet(bit.WithInitialBits(universeSize))
+	universe.SetRange(0, universeSize)
+
+	return &Evaluator{
+		sets:     sets,
+		universe: universe,
+		cache:    make(map[string]*bit.Set),
+	}, nil
+}
+
+// Set evaluates the set expression and returns a new set.
+func (ev *Evaluator) Set(node Node) (*bit.Set, error) {
+	if node.IsNumber() {
+		return nil, fmt.Errorf("Expression %s is not a set", node)
+	}
+
+	result, err := ev.eval(node)
+	if err != nil {
+		return nil, err
+	}
+	return result.Clone(), nil
+}
+
+// Number evaluates the numeric expression.
+func (ev *Evaluator) Number(node Node) (int, error) {
+	call, ok := node.(*Call)
+	if !ok {
+		return 0, fmt.Errorf("Expression %s is not a number", node)
+	}
+
+	arg, err := ev.eval(call.Arg)
+	if err != nil {
+		return 0, err
+	}
+
+	switch call.Func {
+	case "card":
+		return arg.Cardinality(), nil
+	}
+	return 0, fmt.Errorf("Unknown function %q", call.Func)
+}
+
+// Evaluate parses the set expression and evaluates it against the named sets.
+func Evaluate(src string, sets map[string]*bit.Set, universeSize int) (*bit.Set, error) {
+	node, err := Parse(src)
+	if err != nil {
+		return nil, err
+	}
+
+	ev, err := NewEvaluator(sets, universeSize)
+	if err != nil {
+		return nil, err
+	}
+	return ev.Set(node)
+}
+
+// eval returns the cached result of the node, the result must not be modified
+func (ev *Evaluator) eval(node Node) (*bit.Set, error) {
+	key := node.String()
+	if result, ok := ev.cache[key]; ok {
+		return result, nil
+	}
+
+	var result *bit.Set
+	var err error
+
+	switch n := node.(type) {
+	case *Ident:
+		set, ok := ev.sets[n.Name]
+		if !ok {
+			return nil, fmt.Errorf("Unknown set %q", n.Name)
+		}
+		result = set.CloneWithSize(ev.universe.Size()).And(ev.universe)
+
+	case *Const:
+		result = ev.newSet()
+		if n.Universe {
+			result.Or(ev.universe)
+		}
+
+	case *Not:
+		var x *bit.Set
+		if x, err = ev.eval(n.X); err != nil {
+			return nil, err
+		}
+		result = ev.universe.Clone().AndNot(x)
+
+	case *Binary:
+		if result, err = ev.evalBinary(n); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("Expression %s is not a set", node)
+	}
+
+	ev.cache[key] = result
+	return result, nil
+}
+
+func (ev *Evaluator) evalBinary(n *Binary) (*bit.Set, error) {
+	// operands of intersection which are complements are subtracted instead,
+	// so the complement is never computed
+	positives := make([]Node, 0, len(n.Operands))
+	negatives := make([]Node, 0)
+	for _, operand := range n.Operands {
+		if not, ok := operand.(*Not); ok && n.Op == OpAnd {
+			negatives = append(negatives, not.X)
+		} else {
+			positives = append(positives, operand)
+		}
+	}
+
+	sets, err := ev.evalAll(positives)
+	if err != nil {
+		return nil, err
+	}
+	excluded, err := ev.evalAll(negatives)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.Op {
+	case OpAnd:
+		// smallest sets first, so the result shrinks as soon as possible
+		sortByCardinality(sets, true)
+		// largest excluded sets first for the same reason
+		sortByCardinality(excluded, false)
+
+		result := ev.universe.Clone()
+		for _, set := range sets {
+			result.And(set)
+			if result.IsEmpty() {
+				return result, nil
+			}
+		}
+		for _, set := range excluded {
+			result.AndNot(set)
+			if result.IsEmpty() {
+				return result, nil
+			}
+		}
+		return result, nil
+
+	case OpOr:
+		// largest sets first, so the result may become the universe sooner
+		sortByCardinality(sets, false)
+
+		result := ev.newSet()
+		for _, set := range sets {
+			result.Or(set)
+			if result.Equal(ev.universe) {
+				return result, nil
+			}
+		}
+		return result, nil
+	}
+
+	result := ev.newSet()
+	for _, set := range sets {
+		result.Xor(set)
+	}
+	return result, nil
+}
+
+func (ev *Evaluator) evalAll(nodes []Node) ([]*bit.Set, error) {
+	sets := make([]*bit.Set, len(nodes))
+	for i, node := range nodes {
+		set, err := ev.eval(node)
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+	return sets, nil
+}
+
+// newSet returns an empty set with the same size as the universe, see
+// bit.Set.CloneWithSize.
+func (ev *Evaluator) newSet() *bit.Set {
+	set, _ := bit.NewSet(bit.WithInitialBits(ev.universe.Size()))
+	return set
+}
+
+func sortByCardinality(sets []*bit.Set, ascending bool) {
+	cardinalities := make(map[*bit.Set]int, len(sets))
+	for _, set := range sets {
+		cardinalities[set] = set.Cardinality()
+	}
+
+	sort.SliceStable(sets, func(i, j int) bool {
+		if ascending {
+			return cardinalities[sets[i]] < cardinalities[sets[j]]
+		}
+		return cardinalities[sets[i]] > cardinalities[sets[j]]
+	})
+}
@@ -0,0 +1,104 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/mostafa-asg/bit"
+	"github.com/stretchr/testify/assert"
+)
+
+func testSets() map[string]*bit.Set {
+	return map[string]*bit.Set{
+		"a": bit.ValueOf([]uint64{0x0F}),       // {0, 1, 2, 3}
+		"b": bit.ValueOf([]uint64{0x3C}),       // {2, 3, 4, 5}
+		"c": bit.ValueOf([]uint64{0x81}),       // {0, 7}
+		"d": bit.ValueOf([]uint64{0x100, 0x1}), // {8, 64}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	testCases := []struct {
+		src      string
+		expected string
+	}{
+		{src: "a", expected: "{0, 1, 2, 3}"},
+		{src: "a & b", expected: "{2, 3}"},
+		{src: "a | c", expected: "{0, 1, 2, 3, 7}"},
+		{src: "a ^ b", expected: "{0, 1, 4, 5}"},
+		{src: "!c", expected: "{1, 2, 3, 4, 5, 6, 8, 9}"},
+		{src: "a & !b & !c", expected: "{1}"},
+		{src: "!a & !b", expected: "{6, 7, 8, 9}"},
+		{src: "(a & b) | !c ^ d", expected: "{1, 2, 3, 4, 5, 6, 9}"},
+		{src: "0", expected: "{}"},
+		{src: "1", expected: "{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}"},
+		// bits outside the universe are ignored
+		{src: "d", expected: "{8}"},
+	}
+
+	for _, test := range testCases {
+		result, err := Evaluate(test.src, testSets(), 10)
+		assert.NoError(t, err, test.src)
+		assert.Equal(t, test.expected, result.String(), test.src)
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	_, err := Evaluate("a & unknown", testSets(), 10)
+	assert.Error(t, err)
+
+	_, err = Evaluate("card(a)", testSets(), 10)
+	assert.Error(t, err)
+
+	_, err = Evaluate("a &", testSets(), 10)
+	assert.Error(t, err)
+
+	_, err = NewEvaluator(testSets(), -1)
+	assert.Error(t, err)
+}
+
+func TestEvaluatorNumber(t *testing.T) {
+	ev, err := NewEvaluator(testSets(), 100)
+	if err != nil {
+		t.FailNow()
+	}
+
+	testCases := []struct {
+		src      string
+		expected int
+	}{
+		{src: "card(a)", expected: 4},
+		{src: "card(a | b)", expected: 6},
+		{src: "card(!a)", expected: 96},
+		{src: "card(d)", expected: 2},
+		{src: "card(0)", expected: 0},
+	}
+
+	for _, test := range testCases {
+		node, err := Parse(test.src)
+		assert.NoError(t, err)
+		n, err := ev.Number(node)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, n, test.src)
+	}
+
+	node, _ := Parse("a")
+	_, err = ev.Number(node)
+	assert.Error(t, err)
+}
+
+func TestEvaluatorCache(t *testing.T) {
+	ev, _ := NewEvaluator(testSets(), 10)
+
+	node, _ := Parse("(a & b) | (c ^ (b & a))")
+	result, err := ev.Set(node)
+	assert.NoError(t, err)
+	assert.Equal(t, "{0, 2, 3, 7}", result.String())
+	assert.Contains(t, ev.cache, "(a & b)")
+
+	// modifying the result doesn't affect the cache
+	result.ClearAll()
+	node, _ = Parse("b & a")
+	result, err = ev.Set(node)
+	assert.NoError(t, err)
+	assert.Equal(t, "{2, 3}", result.String())
+}
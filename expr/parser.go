@@ -0,0 +1,221 @@
+package expr
+
+import (
+	"fmt"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenConst
+	tokenOp
+	tokenNot
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	pos   int
+	op    Op
+	value bool
+}
+
+// functions maps the name of every known function to whether its
+// argument is a set
+var functions = map[string]bool{
+	"card": true,
+}
+
+// Parse parses the expression and returns its simplified syntax tree.
+func Parse(src string) (Node, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	node, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("Unexpected %q at position %d", tok.text, tok.pos)
+	}
+
+	return Simplify(node), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// parseExpr parses the operator with the lowest precedence
+func (p *parser) parseExpr() (Node, error) {
+	return p.parseBinary(OpOr)
+}
+
+// parseBinary parses a chain of the operator, whose operands are
+// expressions of the operators with higher precedence
+func (p *parser) parseBinary(op Op) (Node, error) {
+	parseOperand := p.parseUnary
+	if op > OpAnd {
+		parseOperand = func() (Node, error) {
+			return p.parseBinary(op - 1)
+		}
+	}
+
+	first, err := parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	operands := []Node{first}
+	for tok := p.peek(); tok.kind == tokenOp && tok.op == op; tok = p.peek() {
+		p.next()
+		operand, err := parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+	}
+
+	if len(operands) == 1 {
+		return first, nil
+	}
+	for _, operand := range operands {
+		if operand.IsNumber() {
+			return nil, fmt.Errorf("Operator %s can't be applied to %s", op, operand)
+		}
+	}
+	return &Binary{Op: op, Operands: operands}, nil
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	if p.peek().kind == tokenNot {
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if x.IsNumber() {
+			return nil, fmt.Errorf("Operator ! can't be applied to %s", x)
+		}
+		return &Not{X: x}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenConst:
+		return &Const{Universe: tok.value}, nil
+
+	case tokenIdent:
+		if p.peek().kind != tokenLParen {
+			return &Ident{Name: tok.text}, nil
+		}
+
+		if _, ok := functions[tok.text]; !ok {
+			return nil, fmt.Errorf("Unknown function %q at position %d", tok.text, tok.pos)
+		}
+		p.next()
+		arg, err := p.parseParenthesized()
+		if err != nil {
+			return nil, err
+		}
+		if arg.IsNumber() {
+			return nil, fmt.Errorf("Function %s can't be applied to %s", tok.text, arg)
+		}
+		return &Call{Func: tok.text, Arg: arg}, nil
+
+	case tokenLParen:
+		return p.parseParenthesized()
+
+	case tokenEOF:
+		return nil, fmt.Errorf("Unexpected end of expression")
+	}
+
+	return nil, fmt.Errorf("Unexpected %q at position %d", tok.text, tok.pos)
+}
+
+// parseParenthesized parses an expression followed by the closing parenthesis
+func (p *parser) parseParenthesized() (Node, error) {
+	node, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.next(); tok.kind != tokenRParen {
+		if tok.kind == tokenEOF {
+			return nil, fmt.Errorf("Missing closing parenthesis")
+		}
+		return nil, fmt.Errorf("Unexpected %q at position %d", tok.text, tok.pos)
+	}
+	return node, nil
+}
+
+func tokenize(src string) ([]token, error) {
+	tokens := make([]token, 0)
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+		case c == '&':
+			tokens = append(tokens, token{kind: tokenOp, text: "&", pos: i, op: OpAnd})
+		case c == '^':
+			tokens = append(tokens, token{kind: tokenOp, text: "^", pos: i, op: OpXor})
+		case c == '|':
+			tokens = append(tokens, token{kind: tokenOp, text: "|", pos: i, op: OpOr})
+		case c == '!':
+			tokens = append(tokens, token{kind: tokenNot, text: "!", pos: i})
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i})
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i})
+		case c == '0' || c == '1':
+			tokens = append(tokens, token{kind: tokenConst, text: string(c), pos: i, value: c == '1'})
+		case isIdentStart(c):
+			start := i
+			for i < len(src) && isIdentPart(src[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: src[start:i], pos: start})
+			continue
+		default:
+			return nil, fmt.Errorf("Unexpected character %q at position %d", c, i)
+		}
+		i++
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(src)}), nil
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || c == '.' || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,81 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		src      string
+		expected string
+	}{
+		{src: "a", expected: "a"},
+		{src: "(a & b) | !c ^ d", expected: "((!c ^ d) | (a & b))"},
+		{src: "a | b & c", expected: "((b & c) | a)"},
+		{src: "a & b & c", expected: "(a & b & c)"},
+		{src: "a & (b & c)", expected: "(a & b & c)"},
+		{src: "c & b & a", expected: "(a & b & c)"},
+		{src: "!!a", expected: "a"},
+		{src: "!(a | b)", expected: "!(a | b)"},
+		{src: "card(x & y)", expected: "card((x & y))"},
+		{src: "seg.pro_2020 & a", expected: "(a & seg.pro_2020)"},
+	}
+
+	for _, test := range testCases {
+		node, err := Parse(test.src)
+		assert.NoError(t, err, test.src)
+		assert.Equal(t, test.expected, node.String(), test.src)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	testCases := []string{
+		"",
+		"a &",
+		"(a | b",
+		"a b",
+		"a $ b",
+		"foo(a)",
+		"card(a) & b",
+		"!card(a)",
+		"card(card(a))",
+		")",
+	}
+
+	for _, src := range testCases {
+		_, err := Parse(src)
+		assert.Error(t, err, src)
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	testCases := []struct {
+		src      string
+		expected string
+	}{
+		{src: "a & 1", expected: "a"},
+		{src: "a & 0", expected: "0"},
+		{src: "a | 0", expected: "a"},
+		{src: "a | 1 | b", expected: "1"},
+		{src: "!0", expected: "1"},
+		{src: "a & a & b", expected: "(a & b)"},
+		{src: "a | b | a", expected: "(a | b)"},
+		{src: "a & !a & b", expected: "0"},
+		{src: "a | !a", expected: "1"},
+		{src: "a ^ a", expected: "0"},
+		{src: "a ^ b ^ a", expected: "b"},
+		{src: "a ^ 1", expected: "!a"},
+		{src: "a ^ b ^ 1 ^ 1", expected: "(a ^ b)"},
+		{src: "!a ^ 1", expected: "a"},
+		{src: "(a & b) | (b & a)", expected: "(a & b)"},
+		{src: "card(a & 0)", expected: "card(0)"},
+	}
+
+	for _, test := range testCases {
+		node, err := Parse(test.src)
+		assert.NoError(t, err, test.src)
+		assert.Equal(t, test.expected, node.String(), test.src)
+	}
+}
@@ -0,0 +1,108 @@
+package expr
+
+import (
+	"sort"
+)
+
+// Simplify returns an equivalent syntax tree in canonical form.
+// Nested operators of the same kind are flattened, operands are sorted,
+// constants are folded and the following identities are applied:
+//
+//	!!x = x      x & x = x     x | x = x     x ^ x = 0
+//	x & !x = 0   x | !x = 1    x ^ 1 = !x
+func Simplify(node Node) Node {
+	switch n := node.(type) {
+	case *Not:
+		x := Simplify(n.X)
+		switch x := x.(type) {
+		case *Const:
+			return &Const{Universe: !x.Universe}
+		case *Not:
+			return x.X
+		}
+		return &Not{X: x}
+
+	case *Binary:
+		return simplifyBinary(n)
+
+	case *Call:
+		return &Call{Func: n.Func, Arg: Simplify(n.Arg)}
+	}
+
+	return node
+}
+
+func simplifyBinary(n *Binary) Node {
+	// flatten
+	operands := make([]Node, 0, len(n.Operands))
+	for _, operand := range n.Operands {
+		operand = Simplify(operand)
+		if b, ok := operand.(*Binary); ok && b.Op == n.Op {
+			operands = append(operands, b.Operands...)
+		} else {
+			operands = append(operands, operand)
+		}
+	}
+
+	// the absorbing element of the operator: x & 0 = 0, x | 1 = 1
+	absorbing := n.Op == OpOr
+	universes := 0
+	counts := make(map[string]int)
+	unique := make([]Node, 0, len(operands))
+
+	for _, operand := range operands {
+		if c, ok := operand.(*Const); ok {
+			if n.Op != OpXor && c.Universe == absorbing {
+				return c
+			}
+			if c.Universe {
+				universes++
+			}
+			continue
+		}
+
+		key := operand.String()
+		if counts[key] == 0 {
+			unique = append(unique, operand)
+		}
+		counts[key]++
+	}
+
+	if n.Op == OpXor {
+		// pairs of equal operands cancel each other out
+		odd := make([]Node, 0, len(unique))
+		for _, operand := range unique {
+			if counts[operand.String()]%2 == 1 {
+				odd = append(odd, operand)
+			}
+		}
+		unique = odd
+	} else {
+		for _, operand := range unique {
+			if not, ok := operand.(*Not); ok && counts[not.X.String()] > 0 {
+				// x & !x = 0, x | !x = 1
+				return &Const{Universe: absorbing}
+			}
+		}
+	}
+
+	sort.Slice(unique, func(i, j int) bool {
+		return unique[i].String() < unique[j].String()
+	})
+
+	var result Node
+	switch len(unique) {
+	case 0:
+		// the identity element of the operator
+		result = &Const{Universe: n.Op == OpAnd}
+	case 1:
+		result = unique[0]
+	default:
+		result = &Binary{Op: n.Op, Operands: unique}
+	}
+
+	if n.Op == OpXor && universes%2 == 1 {
+		return Simplify(&Not{X: result})
+	}
+	return result
+}
@@ -0,0 +1,381 @@
+package bit
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// flagSeparator separates the names of the flags in the text form of a set
+const flagSeparator = "|"
+
+// MaxFlagBit is the highest bit position of a flag, so the sets of flags,
+// including the parsed ones, never need more than 8 KiB.
+const MaxFlagBit = 1<<16 - 1
+
+// FlagRegistry maps names of flags to bit positions, so sets of flags can be
+// stored as bits and shown as text like "read|write|admin".
+//
+// Bit positions are assigned once and never reused: a retired flag keeps its
+// bit, so sets stored by previous versions are never misread. CheckStable
+// verifies this between two versions of the definitions.
+type FlagRegistry struct {
+	names   map[int]string // defined flags by bit position
+	bits    map[string]int // bit positions by name
+	retired map[int]string // retired bit positions with their last name
+	next    int            // the lowest bit position which was never used
+}
+
+// NewFlagRegistry creates a registry without any flags.
+func NewFlagRegistry() *FlagRegistry {
+	return &FlagRegistry{
+		names:   make(map[int]string),
+		bits:    make(map[string]int),
+		retired: make(map[int]string),
+	}
+}
+
+// Define defines a flag on the lowest bit position which was never used,
+// and returns the position.
+func (r *FlagRegistry) Define(name string) (int, error) {
+	bit := r.next
+	if err := r.DefineAt(name, bit); err != nil {
+		return -1, err
+	}
+	return bit, nil
+}
+
+// DefineAt defines a flag on the bit position, which should have never been
+// used by another flag, including the retired ones. The position should not
+// be greater than MaxFlagBit.
+func (r *FlagRegistry) DefineAt(name string, bit int) error {
+	if err := checkFlagName(name); err != nil {
+		return err
+	}
+	if bit < 0 {
+		return fmt.Errorf("%w: %d", ErrNegativeIndex, bit)
+	}
+	if bit > MaxFlagBit {
+		return fmt.Errorf("%w: %d is not in [0, %d]", ErrOutOfBounds, bit, MaxFlagBit)
+	}
+	if _, ok := r.bits[name]; ok {
+		return fmt.Errorf("%w: Flag %q is already defined", ErrInvalidArgument, name)
+	}
+	if used, ok := r.usedBy(bit); ok {
+		return fmt.Errorf("%w: Bit %d is already used by %q", ErrInvalidArgument, bit, used)
+	}
+
+	r.names[bit] = name
+	r.bits[name] = bit
+	if bit >= r.next {
+		r.next = bit + 1
+	}
+	return nil
+}
+
+// Retire removes the flag from the definitions, but its bit position stays
+// reserved, so it is never assigned to another flag.
+func (r *FlagRegistry) Retire(name string) error {
+	bit, ok := r.bits[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownFlag, name)
+	}
+
+	delete(r.names, bit)
+	delete(r.bits, name)
+	r.retired[bit] = name
+	return nil
+}
+
+// Bit returns the bit position of the flag.
+func (r *FlagRegistry) Bit(name string) (int, bool) {
+	bit, ok := r.bits[name]
+	return bit, ok
+}
+
+// Name returns the name of the flag defined on the bit position.
+func (r *FlagRegistry) Name(bit int) (string, bool) {
+	name, ok := r.names[bit]
+	return name, ok
+}
+
+// Names returns the names of the defined flags ordered by bit position.
+func (r *FlagRegistry) Names() []string {
+	bits := make([]int, 0, len(r.names))
+	for bit := range r.names {
+		bits = append(bits, bit)
+	}
+	sort.Ints(bits)
+
+	names := make([]string, len(bits))
+	for i, bit := range bits {
+		names[i] = r.names[bit]
+	}
+	return names
+}
+
+// Parse returns a new set of the flags separated by "|", like "read|write".
+// Spaces around the names are ignored, and an empty string is an empty set.
+// A decimal number stands for the bit position itself, as written by Format
+// for bits without a defined flag, but only positions which the registry has
+// assigned, like the retired ones, are accepted. Any other name fails with
+// ErrUnknownFlag, so the set never holds a bit past MaxFlagBit.
+func (r *FlagRegistry) Parse(s string) (*Set, error) {
+	set, _ := NewSet()
+	if strings.TrimSpace(s) == "" {
+		return set, nil
+	}
+
+	for _, name := range strings.Split(s, flagSeparator) {
+		bit, err := r.parseFlag(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		set.Set(bit)
+	}
+	return set, nil
+}
+
+// Format returns the names of the flags of the set separated by "|" in bit
+// order, like "read|write". Set bits without a defined flag are written as
+// their decimal position, so nothing is lost. Parse accepts them back only
+// below the lowest bit position which was never used.
+func (r *FlagRegistry) Format(set *Set) string {
+	return strings.Join(r.format(set), flagSeparator)
+}
+
+// CheckStable checks that these definitions are a compatible evolution of
+// the previous definitions: every flag of the previous version is either
+// defined on the same bit or retired, and no retired bit is used again.
+func (r *FlagRegistry) CheckStable(previous *FlagRegistry) error {
+	for bit, name := range previous.names {
+		if current, ok := r.names[bit]; ok && current != name {
+			return fmt.Errorf("%w: Bit %d is renamed from %q to %q", ErrUnstableFlags, bit, name, current)
+		}
+		if _, ok := r.usedBy(bit); !ok {
+			return fmt.Errorf("%w: Flag %q is removed without retiring bit %d", ErrUnstableFlags, name, bit)
+		}
+		if current, ok := r.bits[name]; ok && current != bit {
+			return fmt.Errorf("%w: Flag %q is moved from bit %d to %d", ErrUnstableFlags, name, bit, current)
+		}
+	}
+
+	for bit, name := range previous.retired {
+		if current, ok := r.names[bit]; ok {
+			return fmt.Errorf("%w: Retired bit %d of %q is reused by %q", ErrUnstableFlags, bit, name, current)
+		}
+		if _, ok := r.retired[bit]; !ok {
+			return fmt.Errorf("%w: Retired bit %d of %q is released", ErrUnstableFlags, bit, name)
+		}
+	}
+
+	return nil
+}
+
+// Flags returns the set bound to this registry, so it is formatted and
+// marshaled to JSON with the names of the flags. If set is nil, a new empty
+// set is used.
+func (r *FlagRegistry) Flags(set *Set) *Flags {
+	if set == nil {
+		set, _ = NewSet()
+	}
+	return &Flags{registry: r, set: set}
+}
+
+// flagJSON is a definition of a flag in the JSON form of a registry
+type flagJSON struct {
+	Name    string `json:"name"`
+	Bit     int    `json:"bit"`
+	Retired bool   `json:"retired,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. The registry is written as a list of
+// the definitions ordered by bit position, including the retired ones, so
+// it can be stored and checked with CheckStable by the next version.
+func (r *FlagRegistry) MarshalJSON() ([]byte, error) {
+	flags := make([]flagJSON, 0, len(r.names)+len(r.retired))
+	for bit, name := range r.names {
+		flags = append(flags, flagJSON{Name: name, Bit: bit})
+	}
+	for bit, name := range r.retired {
+		flags = append(flags, flagJSON{Name: name, Bit: bit, Retired: true})
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Bit < flags[j].Bit })
+
+	return json.Marshal(flags)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *FlagRegistry) UnmarshalJSON(data []byte) error {
+	var flags []flagJSON
+	if err := json.Unmarshal(data, &flags); err != nil {
+		return fmt.Errorf("%w: %v", ErrCorruptData, err)
+	}
+
+	decoded := NewFlagRegistry()
+	for _, flag := range flags {
+		if flag.Retired {
+			if _, ok := decoded.usedBy(flag.Bit); ok || flag.Bit < 0 || flag.Bit > MaxFlagBit {
+				return fmt.Errorf("%w: Flag registry", ErrCorruptData)
+			}
+			decoded.retired[flag.Bit] = flag.Name
+			if flag.Bit >= decoded.next {
+				decoded.next = flag.Bit + 1
+			}
+			continue
+		}
+
+		if err := decoded.DefineAt(flag.Name, flag.Bit); err != nil {
+			return fmt.Errorf("%w: %v", ErrCorruptData, err)
+		}
+	}
+
+	*r = *decoded
+	return nil
+}
+
+// usedBy returns the name of the flag which uses or used the bit position
+func (r *FlagRegistry) usedBy(bit int) (string, bool) {
+	if name, ok := r.names[bit]; ok {
+		return name, true
+	}
+	name, ok := r.retired[bit]
+	return name, ok
+}
+
+func (r *FlagRegistry) parseFlag(name string) (int, error) {
+	if bit, ok := r.bits[name]; ok {
+		return bit, nil
+	}
+	if bit, err := strconv.Atoi(name); err == nil && bit >= 0 && bit < r.next {
+		return bit, nil
+	}
+
+	return -1, fmt.Errorf("%w: %q", ErrUnknownFlag, name)
+}
+
+func (r *FlagRegistry) format(set *Set) []string {
+	names := make([]string, 0, set.Cardinality())
+	set.Iterate(func(bit int) bool {
+		if name, ok := r.names[bit]; ok {
+			names = append(names, name)
+		} else {
+			names = append(names, strconv.Itoa(bit))
+		}
+		return true
+	})
+	return names
+}
+
+// checkFlagName checks that the name can be parsed back from the text form
+func checkFlagName(name string) error {
+	if name == "" || strings.TrimSpace(name) != name || strings.Contains(name, flagSeparator) {
+		return fmt.Errorf("%w: Flag name %q", ErrInvalidArgument, name)
+	}
+	if _, err := strconv.Atoi(name); err == nil {
+		return fmt.Errorf("%w: Flag name %q is a number", ErrInvalidArgument, name)
+	}
+	return nil
+}
+
+// Flags is a set bound to a FlagRegistry. It is formatted like "read|write"
+// and marshaled to JSON as a list of the names of the flags.
+type Flags struct {
+	registry *FlagRegistry
+	set      *Set
+}
+
+// BitSet returns the underlying set.
+func (f *Flags) BitSet() *Set {
+	return f.set
+}
+
+// Has checks whether the flag is set.
+func (f *Flags) Has(name string) bool {
+	bit, ok := f.registry.bits[name]
+	return ok && f.set.Get(bit)
+}
+
+// Add sets the flags, or returns an error without any change if one of
+// them is not defined.
+func (f *Flags) Add(names ...string) error {
+	bits, err := f.lookup(names)
+	if err != nil {
+		return err
+	}
+
+	for _, bit := range bits {
+		f.set.Set(bit)
+	}
+	return nil
+}
+
+// Remove clears the flags, or returns an error without any change if one of
+// them is not defined.
+func (f *Flags) Remove(names ...string) error {
+	bits, err := f.lookup(names)
+	if err != nil {
+		return err
+	}
+
+	for _, bit := range bits {
+		f.set.Clear(bit)
+	}
+	return nil
+}
+
+// String returns the flags like FlagRegistry.Format.
+func (f *Flags) String() string {
+	return f.registry.Format(f.set)
+}
+
+// MarshalJSON implements json.Marshaler, the flags are written as a list of
+// names like ["read","write"].
+func (f *Flags) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.registry.format(f.set))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, the flags are replaced with
+// the list of names. The registry should be bound before, by FlagRegistry.Flags.
+func (f *Flags) UnmarshalJSON(data []byte) error {
+	if f.registry == nil {
+		return fmt.Errorf("%w: Flags are not bound to a registry", ErrInvalidArgument)
+	}
+
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return fmt.Errorf("%w: %v", ErrCorruptData, err)
+	}
+
+	set, _ := NewSet()
+	for _, name := range names {
+		bit, err := f.registry.parseFlag(name)
+		if err != nil {
+			return err
+		}
+		set.Set(bit)
+	}
+
+	if f.set == nil {
+		f.set = set
+		return nil
+	}
+	f.set.ClearAll()
+	f.set.expandIfNeeded(len(set.arr) - 1)
+	f.set.Or(set)
+	return nil
+}
+
+func (f *Flags) lookup(names []string) ([]int, error) {
+	bits := make([]int, len(names))
+	for i, name := range names {
+		bit, ok := f.registry.bits[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownFlag, name)
+		}
+		bits[i] = bit
+	}
+	return bits, nil
+}
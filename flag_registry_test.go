@@ -0,0 +1,177 @@
+package bit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newPermissions(t *testing.T) *FlagRegistry {
+	r := NewFlagRegistry()
+	for _, name := range []string{"read", "write", "admin"} {
+		if _, err := r.Define(name); err != nil {
+			t.FailNow()
+		}
+	}
+	return r
+}
+
+func TestFlagRegistryDefine(t *testing.T) {
+	r := newPermissions(t)
+
+	bit, ok := r.Bit("admin")
+	assert.True(t, ok)
+	assert.Equal(t, 2, bit)
+	name, ok := r.Name(1)
+	assert.True(t, ok)
+	assert.Equal(t, "write", name)
+
+	assert.NoError(t, r.DefineAt("audit", 10))
+	bit, err := r.Define("export")
+	assert.NoError(t, err)
+	assert.Equal(t, 11, bit)
+
+	_, err = r.Define("read")
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	assert.ErrorIs(t, r.DefineAt("delete", 10), ErrInvalidArgument)
+	assert.ErrorIs(t, r.DefineAt("delete", -1), ErrNegativeIndex)
+	for _, name := range []string{"", " read", "a|b", "42"} {
+		_, err = r.Define(name)
+		assert.ErrorIs(t, err, ErrInvalidArgument, name)
+	}
+
+	assert.NoError(t, r.Retire("write"))
+	assert.ErrorIs(t, r.Retire("write"), ErrUnknownFlag)
+	assert.ErrorIs(t, r.DefineAt("delete", 1), ErrInvalidArgument)
+	bit, err = r.Define("delete")
+	assert.NoError(t, err)
+	assert.Equal(t, 12, bit)
+
+	assert.Equal(t, []string{"read", "admin", "audit", "export", "delete"}, r.Names())
+
+	assert.ErrorIs(t, r.DefineAt("huge", MaxFlagBit+1), ErrOutOfBounds)
+	assert.NoError(t, r.DefineAt("last", MaxFlagBit))
+	_, err = r.Define("more")
+	assert.ErrorIs(t, err, ErrOutOfBounds)
+}
+
+func TestFlagRegistryParseFormat(t *testing.T) {
+	r := newPermissions(t)
+
+	set, err := r.Parse("read | admin")
+	assert.NoError(t, err)
+	assert.Equal(t, "{0, 2}", set.String())
+	assert.Equal(t, "read|admin", r.Format(set))
+
+	assert.NoError(t, r.Retire("write"))
+	set.Set(1)
+	assert.Equal(t, "read|1|admin", r.Format(set))
+	parsed, err := r.Parse(r.Format(set))
+	assert.NoError(t, err)
+	assert.True(t, set.Equal(parsed))
+
+	set.Set(7)
+	assert.Equal(t, "read|1|admin|7", r.Format(set))
+	_, err = r.Parse(r.Format(set))
+	assert.ErrorIs(t, err, ErrUnknownFlag)
+	_, err = r.Parse("read|3")
+	assert.ErrorIs(t, err, ErrUnknownFlag)
+	_, err = r.Parse("read|4000000000")
+	assert.ErrorIs(t, err, ErrUnknownFlag)
+
+	empty, err := r.Parse("")
+	assert.NoError(t, err)
+	assert.True(t, empty.IsEmpty())
+	assert.Equal(t, "", r.Format(empty))
+
+	_, err = r.Parse("read|delete")
+	assert.ErrorIs(t, err, ErrUnknownFlag)
+	_, err = r.Parse("read||write")
+	assert.ErrorIs(t, err, ErrUnknownFlag)
+}
+
+func TestFlagRegistryCheckStable(t *testing.T) {
+	previous := newPermissions(t)
+	previous.Retire("write")
+
+	current := newPermissions(t)
+	current.Retire("write")
+	current.Define("audit")
+	assert.NoError(t, current.CheckStable(previous))
+
+	removed := NewFlagRegistry()
+	removed.Define("read")
+	assert.ErrorIs(t, removed.CheckStable(previous), ErrUnstableFlags)
+
+	renamed := NewFlagRegistry()
+	renamed.Define("read")
+	renamed.DefineAt("writer", 1)
+	renamed.Retire("writer")
+	renamed.Define("superuser")
+	assert.ErrorIs(t, renamed.CheckStable(previous), ErrUnstableFlags)
+
+	reused := NewFlagRegistry()
+	reused.Define("read")
+	reused.Define("delete")
+	reused.Define("admin")
+	assert.ErrorIs(t, reused.CheckStable(previous), ErrUnstableFlags)
+
+	moved := newPermissions(t)
+	moved.Retire("write")
+	moved.Retire("admin")
+	moved.Define("admin")
+	assert.ErrorIs(t, moved.CheckStable(previous), ErrUnstableFlags)
+}
+
+func TestFlagRegistryJSON(t *testing.T) {
+	r := newPermissions(t)
+	r.Retire("write")
+
+	data, err := json.Marshal(r)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"name":"read","bit":0},{"name":"write","bit":1,"retired":true},{"name":"admin","bit":2}]`, string(data))
+
+	decoded := NewFlagRegistry()
+	assert.NoError(t, json.Unmarshal(data, decoded))
+	assert.NoError(t, decoded.CheckStable(r))
+	assert.NoError(t, r.CheckStable(decoded))
+	bit, err := decoded.Define("audit")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, bit)
+
+	assert.ErrorIs(t, decoded.UnmarshalJSON([]byte(`[{"name":"a","bit":0},{"name":"b","bit":0}]`)), ErrCorruptData)
+	assert.ErrorIs(t, decoded.UnmarshalJSON([]byte(`{`)), ErrCorruptData)
+	assert.ErrorIs(t, decoded.UnmarshalJSON([]byte(`[{"name":"a","bit":1099511627776}]`)), ErrCorruptData)
+	assert.ErrorIs(t, decoded.UnmarshalJSON([]byte(`[{"name":"a","bit":1099511627776,"retired":true}]`)), ErrCorruptData)
+	assert.Equal(t, 4, decoded.next)
+}
+
+func TestFlags(t *testing.T) {
+	r := newPermissions(t)
+
+	flags := r.Flags(nil)
+	assert.NoError(t, flags.Add("read", "admin"))
+	assert.ErrorIs(t, flags.Add("write", "delete"), ErrUnknownFlag)
+	assert.False(t, flags.Has("write"))
+	assert.True(t, flags.Has("admin"))
+	assert.Equal(t, "read|admin", flags.String())
+
+	data, err := json.Marshal(flags)
+	assert.NoError(t, err)
+	assert.Equal(t, `["read","admin"]`, string(data))
+
+	assert.NoError(t, flags.Remove("read"))
+	assert.Equal(t, "admin", flags.String())
+
+	set, _ := NewSet()
+	set.Set(100)
+	decoded := r.Flags(set)
+	assert.NoError(t, json.Unmarshal([]byte(`["write","read"]`), decoded))
+	assert.Equal(t, "{0, 1}", set.String())
+	assert.ErrorIs(t, json.Unmarshal([]byte(`["delete"]`), decoded), ErrUnknownFlag)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`["4000000000"]`), decoded), ErrUnknownFlag)
+
+	var unbound Flags
+	assert.ErrorIs(t, json.Unmarshal(data, &unbound), ErrInvalidArgument)
+}
@@ -0,0 +1,268 @@
+// Package graph implements bit-parallel algorithms over directed graphs
+// whose adjacency rows are stored as bit sets.
+package graph
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mostafa-asg/bit"
+)
+
+// ErrCycle is returned when an order of the vertices doesn't exist because
+// the graph has a cycle.
+var ErrCycle = errors.New("Graph has a cycle")
+
+// Graph is a directed graph with vertices numbered from 0 to Len()-1.
+// The successors of every vertex are stored as a bit set.
+type Graph struct {
+	n   int
+	adj []*bit.Set
+}
+
+// New creates a graph with n vertices and no edges.
+func New(n int) (*Graph, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("%w: Number of vertices is negative", bit.ErrInvalidArgument)
+	}
+
+	g := &Graph{
+		n:   n,
+		adj: make([]*bit.Set, n),
+	}
+	for v := range g.adj {
+		g.adj[v] = g.newSet()
+	}
+
+	return g, nil
+}
+
+// FromMatrix creates a graph from the adjacency matrix, in which bit (i, j)
+// is true if there is an edge from i to j. The matrix should be square.
+func FromMatrix(m *bit.Matrix) (*Graph, error) {
+	if m.Rows() != m.Cols() {
+		return nil, fmt.Errorf("%w: Adjacency matrix is not square", bit.ErrInvalidArgument)
+	}
+
+	g, err := New(m.Rows())
+	if err != nil {
+		return nil, err
+	}
+	for v := range g.adj {
+		g.adj[v].Or(m.Row(v))
+	}
+
+	return g, nil
+}
+
+// FromRows creates a graph with a vertex for every row, where row i holds the
+// successors of vertex i. The rows are copied, and bits which are not vertices
+// of the graph are dropped. A nil row has no successors.
+func FromRows(rows []*bit.Set) *Graph {
+	g, _ := New(len(rows))
+	for v, row := range rows {
+		if row == nil {
+			continue
+		}
+		g.adj[v].Or(row)
+		g.adj[v].ClearRange(g.n, g.adj[v].Size())
+	}
+
+	return g
+}
+
+// Len returns the number of vertices.
+func (g *Graph) Len() int {
+	return g.n
+}
+
+// AddEdge adds an edge from one vertex to another.
+// If any of the vertices is outside the graph no change will happen.
+func (g *Graph) AddEdge(from int, to int) *Graph {
+	if g.contains(from) && g.contains(to) {
+		g.adj[from].Set(to)
+	}
+	return g
+}
+
+// RemoveEdge removes the edge from one vertex to another.
+func (g *Graph) RemoveEdge(from int, to int) *Graph {
+	if g.contains(from) && g.contains(to) {
+		g.adj[from].Clear(to)
+	}
+	return g
+}
+
+// HasEdge returns true if there is an edge from one vertex to another.
+func (g *Graph) HasEdge(from int, to int) bool {
+	if !g.contains(from) {
+		return false
+	}
+
+	return g.adj[from].Get(to)
+}
+
+// Successors returns a copy of the successors of the vertex.
+// If the vertex is outside the graph, nil is returned.
+func (g *Graph) Successors(v int) *bit.Set {
+	if !g.contains(v) {
+		return nil
+	}
+
+	return g.adj[v].Clone()
+}
+
+// Clone creates a new copy of the graph
+func (g *Graph) Clone() *Graph {
+	result := &Graph{
+		n:   g.n,
+		adj: make([]*bit.Set, g.n),
+	}
+	for v, successors := range g.adj {
+		result.adj[v] = successors.Clone()
+	}
+	return result
+}
+
+// TransitiveClosure returns a new graph with an edge from i to j if and only
+// if j is reachable from i by a path of one or more edges.
+// It uses the bit-parallel Warshall algorithm, which takes O(n³/64) time.
+func (g *Graph) TransitiveClosure() *Graph {
+	closure := g.Clone()
+
+	for k, row := range closure.adj {
+		for _, successors := range closure.adj {
+			if successors.Get(k) {
+				successors.Or(row)
+			}
+		}
+	}
+
+	return closure
+}
+
+// BFS runs a breadth-first search from the source vertex and returns the
+// frontiers, so the i-th set holds the vertices at distance i from the source.
+// If the source is outside the graph, nil is returned.
+func (g *Graph) BFS(source int) []*bit.Set {
+	if !g.contains(source) {
+		return nil
+	}
+
+	visited := g.newSet().Set(source)
+	frontier := g.newSet().Set(source)
+	frontiers := []*bit.Set{frontier}
+
+	for {
+		next := g.newSet()
+		frontier.Iterate(func(v int) bool {
+			next.Or(g.adj[v])
+			return true
+		})
+		next.AndNot(visited)
+
+		if next.IsEmpty() {
+			return frontiers
+		}
+
+		visited.Or(next)
+		frontiers = append(frontiers, next)
+		frontier = next
+	}
+}
+
+// Reachable returns the vertices reachable from the source vertex,
+// including the source itself.
+func (g *Graph) Reachable(source int) *bit.Set {
+	reachable := g.newSet()
+	if !g.contains(source) {
+		return reachable
+	}
+
+	// unlike BFS, keep no frontiers, so long paths need no memory per level
+	reachable.Set(source)
+	queue := []int{source}
+	for i := 0; i < len(queue); i++ {
+		g.adj[queue[i]].Iterate(func(w int) bool {
+			if !reachable.Get(w) {
+				reachable.Set(w)
+				queue = append(queue, w)
+			}
+			return true
+		})
+	}
+	return reachable
+}
+
+// ConnectedComponents returns the weakly connected components of the graph,
+// which are the connected components when the direction of edges is ignored.
+// The components are ordered by their smallest vertex.
+func (g *Graph) ConnectedComponents() []*bit.Set {
+	undirected := g.Clone()
+	for v, successors := range g.adj {
+		successors.Iterate(func(w int) bool {
+			undirected.adj[w].Set(v)
+			return true
+		})
+	}
+
+	components := make([]*bit.Set, 0)
+	assigned := g.newSet()
+	v := 0
+	for {
+		v, _ = assigned.NextClearBit(v)
+		if v >= g.n {
+			return components
+		}
+
+		component := undirected.Reachable(v)
+		assigned.Or(component)
+		components = append(components, component)
+	}
+}
+
+// TopologicalOrder returns the vertices ordered so that every edge goes from
+// an earlier vertex to a later one, using Kahn's algorithm.
+// ErrCycle is returned if the graph has a cycle.
+func (g *Graph) TopologicalOrder() ([]int, error) {
+	indegree := make([]int, g.n)
+	for _, successors := range g.adj {
+		successors.Iterate(func(w int) bool {
+			indegree[w]++
+			return true
+		})
+	}
+
+	order := make([]int, 0, g.n)
+	for v, degree := range indegree {
+		if degree == 0 {
+			order = append(order, v)
+		}
+	}
+
+	// order is also used as the queue of vertices without incoming edges
+	for i := 0; i < len(order); i++ {
+		g.adj[order[i]].Iterate(func(w int) bool {
+			indegree[w]--
+			if indegree[w] == 0 {
+				order = append(order, w)
+			}
+			return true
+		})
+	}
+
+	if len(order) != g.n {
+		return nil, ErrCycle
+	}
+
+	return order, nil
+}
+
+func (g *Graph) newSet() *bit.Set {
+	set, _ := bit.NewSet(bit.WithInitialBits(g.n))
+	return set
+}
+
+func (g *Graph) contains(v int) bool {
+	return v >= 0 && v < g.n
+}
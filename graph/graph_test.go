@@ -0,0 +1,173 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/mostafa-asg/bit"
+	"github.com/stretchr/testify/assert"
+)
+
+// newGraph creates a graph with n vertices and the given edges
+func newGraph(t *testing.T, n int, edges [][2]int) *Graph {
+	g, err := New(n)
+	if err != nil {
+		t.FailNow()
+	}
+	for _, edge := range edges {
+		g.AddEdge(edge[0], edge[1])
+	}
+	return g
+}
+
+func TestEdges(t *testing.T) {
+	g := newGraph(t, 3, [][2]int{{0, 1}, {1, 2}, {2, 3}, {-1, 0}})
+
+	assert.True(t, g.HasEdge(0, 1))
+	assert.True(t, g.HasEdge(1, 2))
+	assert.False(t, g.HasEdge(1, 0))
+	assert.False(t, g.HasEdge(2, 3))
+	assert.Nil(t, g.Successors(3))
+
+	g.RemoveEdge(0, 1)
+	assert.False(t, g.HasEdge(0, 1))
+
+	_, err := New(-1)
+	assert.Error(t, err)
+}
+
+func TestFromMatrix(t *testing.T) {
+	m, _ := bit.NewMatrix(2, 2)
+	m.Set(0, 1)
+
+	g, err := FromMatrix(m)
+	assert.NoError(t, err)
+	assert.True(t, g.HasEdge(0, 1))
+	assert.False(t, g.HasEdge(1, 0))
+
+	m, _ = bit.NewMatrix(2, 3)
+	_, err = FromMatrix(m)
+	assert.Error(t, err)
+}
+
+func TestFromRows(t *testing.T) {
+	first, _ := bit.NewSet()
+	first.Set(1).Set(2).Set(100)
+	second, _ := bit.NewSet()
+	second.Set(0)
+
+	g := FromRows([]*bit.Set{first, second, nil})
+	assert.Equal(t, 3, g.Len())
+	assert.True(t, g.HasEdge(0, 1))
+	assert.True(t, g.HasEdge(0, 2))
+	assert.True(t, g.HasEdge(1, 0))
+	assert.Equal(t, "{1, 2}", g.Successors(0).String())
+	assert.True(t, g.Successors(2).IsEmpty())
+
+	g.AddEdge(1, 2)
+	assert.Equal(t, "{0}", second.String(), "rows are copied")
+	assert.Equal(t, 0, FromRows(nil).Len())
+}
+
+func TestTransitiveClosure(t *testing.T) {
+	g := newGraph(t, 5, [][2]int{{0, 1}, {1, 2}, {2, 0}, {3, 4}})
+	closure := g.TransitiveClosure()
+
+	testCases := []struct {
+		from     int
+		expected *bit.Set
+	}{
+		{from: 0, expected: bit.ValueOf([]uint64{7})},
+		{from: 1, expected: bit.ValueOf([]uint64{7})},
+		{from: 3, expected: bit.ValueOf([]uint64{16})},
+		{from: 4, expected: bit.ValueOf([]uint64{0})},
+	}
+
+	for _, test := range testCases {
+		assert.True(t, test.expected.Equal(closure.Successors(test.from)))
+	}
+
+	// original graph is not modified
+	assert.False(t, g.HasEdge(0, 2))
+}
+
+func TestBFS(t *testing.T) {
+	g := newGraph(t, 6, [][2]int{{0, 1}, {0, 2}, {1, 3}, {2, 3}, {3, 0}, {4, 5}})
+
+	frontiers := g.BFS(0)
+	assert.Equal(t, 3, len(frontiers))
+	assert.Equal(t, "{0}", frontiers[0].String())
+	assert.Equal(t, "{1, 2}", frontiers[1].String())
+	assert.Equal(t, "{3}", frontiers[2].String())
+
+	assert.Equal(t, "{0, 1, 2, 3}", g.Reachable(0).String())
+	assert.Equal(t, "{5}", g.Reachable(5).String())
+	assert.Nil(t, g.BFS(6))
+}
+
+func TestConnectedComponents(t *testing.T) {
+	g := newGraph(t, 7, [][2]int{{1, 0}, {2, 1}, {4, 3}, {6, 4}})
+
+	components := g.ConnectedComponents()
+	assert.Equal(t, 3, len(components))
+	assert.Equal(t, "{0, 1, 2}", components[0].String())
+	assert.Equal(t, "{3, 4, 6}", components[1].String())
+	assert.Equal(t, "{5}", components[2].String())
+}
+
+func TestTopologicalOrder(t *testing.T) {
+	g := newGraph(t, 5, [][2]int{{3, 1}, {1, 0}, {4, 0}, {2, 4}, {3, 2}})
+
+	order, err := g.TopologicalOrder()
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(order))
+
+	position := make(map[int]int)
+	for i, v := range order {
+		position[v] = i
+	}
+	for v := 0; v < g.Len(); v++ {
+		for w := 0; w < g.Len(); w++ {
+			if g.HasEdge(v, w) {
+				assert.Less(t, position[v], position[w])
+			}
+		}
+	}
+
+	g.AddEdge(0, 3)
+	_, err = g.TopologicalOrder()
+	assert.ErrorIs(t, err, ErrCycle)
+}
+
+func TestLargeChain(t *testing.T) {
+	const n = 5000
+
+	// two chains with edges from higher to lower vertices:
+	// the even vertices and the odd vertices
+	g, err := New(n)
+	if err != nil {
+		t.FailNow()
+	}
+	for v := 2; v < n; v++ {
+		g.AddEdge(v, v-2)
+	}
+
+	order, err := g.TopologicalOrder()
+	assert.NoError(t, err)
+	assert.Equal(t, n, len(order))
+	position := make([]int, n)
+	for i, v := range order {
+		position[v] = i
+	}
+	for v := 2; v < n; v++ {
+		assert.Less(t, position[v], position[v-2])
+	}
+
+	components := g.ConnectedComponents()
+	assert.Equal(t, 2, len(components))
+	assert.Equal(t, n/2, components[0].Cardinality())
+	assert.True(t, components[0].Get(n-2))
+	assert.True(t, components[1].Get(n-1))
+
+	assert.Equal(t, n/2, g.Reachable(n-1).Cardinality())
+	assert.Equal(t, 1, g.Reachable(1).Cardinality())
+}
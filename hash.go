@@ -0,0 +1,65 @@
+package bit
+
+import (
+	"hash/fnv"
+	"math/bits"
+)
+
+const (
+	// FNV-1a parameters of 64 bits
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// Hash returns the 64-bit FNV-1a hash of the bytes returned by Key.
+// Sets which are Equal have the same hash, regardless of their Size.
+// It doesn't allocate.
+func (set *Set) Hash() uint64 {
+	h := uint64(fnvOffset64)
+	n := set.keyLen()
+	for i := 0; i < n; i++ {
+		h ^= uint64(byte(set.arr[i/8] >> uint(i%8*8)))
+		h *= fnvPrime64
+	}
+
+	return h
+}
+
+// Key returns a canonical form of the set, which is the little endian bytes
+// of Bytes without the trailing zero bytes. Sets which are Equal have the same
+// key regardless of their Size, so it can be used as a map key.
+// FromByteArray([]byte(key)) returns a set which is Equal to the set.
+func (set *Set) Key() string {
+	return string(set.keyBytes())
+}
+
+// Fingerprint128 returns the 128-bit FNV-1a hash of the bytes returned by Key,
+// for deduplication where 64-bit hashes are likely to collide.
+func (set *Set) Fingerprint128() [16]byte {
+	h := fnv.New128a()
+	h.Write(set.keyBytes())
+
+	var fingerprint [16]byte
+	h.Sum(fingerprint[:0])
+	return fingerprint
+}
+
+func (set *Set) keyBytes() []byte {
+	key := make([]byte, set.keyLen())
+	for i := range key {
+		key[i] = byte(set.arr[i/8] >> uint(i%8*8))
+	}
+
+	return key
+}
+
+// keyLen returns the number of bytes up to the highest non-zero byte
+func (set *Set) keyLen() int {
+	for i := len(set.arr) - 1; i >= 0; i-- {
+		if set.arr[i] != 0 {
+			return i*8 + (bits.Len64(set.arr[i])+7)/8
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,46 @@
+package bit
+
+import (
+	"hash/fnv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHash(t *testing.T) {
+	small, _ := NewSet()
+	large, _ := NewSet(WithInitialBits(1000))
+	small.Set(3).Set(70)
+	large.Set(3).Set(70)
+
+	assert.True(t, small.Equal(large))
+	assert.Equal(t, small.Hash(), large.Hash())
+	assert.Equal(t, small.Key(), large.Key())
+	assert.Equal(t, small.Fingerprint128(), large.Fingerprint128())
+	assert.Equal(t, "\x08\x00\x00\x00\x00\x00\x00\x00\x40", small.Key())
+
+	h := fnv.New64a()
+	h.Write([]byte(small.Key()))
+	assert.Equal(t, h.Sum64(), small.Hash())
+
+	large.Set(71)
+	assert.NotEqual(t, small.Hash(), large.Hash())
+	assert.NotEqual(t, small.Key(), large.Key())
+	assert.NotEqual(t, small.Fingerprint128(), large.Fingerprint128())
+
+	assert.True(t, small.Equal(FromByteArray([]byte(small.Key()))))
+
+	empty, _ := NewSet(WithInitialBits(500))
+	assert.Equal(t, "", empty.Key())
+	assert.Equal(t, uint64(fnvOffset64), empty.Hash())
+}
+
+func TestKeyAsMapKey(t *testing.T) {
+	seen := make(map[string]int)
+	for _, size := range []int{64, 128, 1000} {
+		set, _ := NewSet(WithInitialBits(size))
+		set.Set(5)
+		seen[set.Key()]++
+	}
+	assert.Equal(t, map[string]int{"\x20": 3}, seen)
+}
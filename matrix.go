@@ -0,0 +1,271 @@
+package bit
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Matrix is a two-dimensional matrix of bits. Every row is stored as a Set,
+// so row operations run at word speed.
+type Matrix struct {
+	rows int
+	cols int
+	arr  []*Set
+}
+
+// NewMatrix creates a rows×cols matrix with all the bits set to false.
+func NewMatrix(rows int, cols int) (*Matrix, error) {
+	if rows < 0 || cols < 0 {
+		return nil, fmt.Errorf("%w: Number of rows or columns is negative", ErrInvalidArgument)
+	}
+
+	m := &Matrix{
+		rows: rows,
+		cols: cols,
+		arr:  make([]*Set, rows),
+	}
+	for r := range m.arr {
+		m.arr[r] = m.newRow()
+	}
+
+	return m, nil
+}
+
+// Rows returns the number of rows.
+func (m *Matrix) Rows() int {
+	return m.rows
+}
+
+// Cols returns the number of columns.
+func (m *Matrix) Cols() int {
+	return m.cols
+}
+
+// Get returns the value of the bit at the specified row and column.
+// If the position is outside the matrix, always false will be returned.
+func (m *Matrix) Get(r int, c int) bool {
+	if !m.contains(r, c) {
+		return false
+	}
+
+	return m.arr[r].Get(c)
+}
+
+// Set sets the bit at the specified row and column to true.
+// If the position is outside the matrix no change will happen.
+func (m *Matrix) Set(r int, c int) *Matrix {
+	if m.contains(r, c) {
+		m.arr[r].Set(c)
+	}
+	return m
+}
+
+// Clear sets the bit at the specified row and column to false.
+// If the position is outside the matrix no change will happen.
+func (m *Matrix) Clear(r int, c int) *Matrix {
+	if m.contains(r, c) {
+		m.arr[r].Clear(c)
+	}
+	return m
+}
+
+// SetValue sets the bit at the specified row and column to the specified value.
+func (m *Matrix) SetValue(r int, c int, value bool) *Matrix {
+	if value {
+		return m.Set(r, c)
+	}
+
+	return m.Clear(r, c)
+}
+
+// Row returns a copy of the specified row. If the row is outside the
+// matrix, nil is returned.
+func (m *Matrix) Row(r int) *Set {
+	if r < 0 || r >= m.rows {
+		return nil
+	}
+
+	return m.arr[r].Clone()
+}
+
+// SetRow replaces the specified row with the first Cols() bits of the set.
+// If the row is outside the matrix no change will happen.
+func (m *Matrix) SetRow(r int, set *Set) *Matrix {
+	if r < 0 || r >= m.rows {
+		return m
+	}
+
+	row := m.newRow()
+	copy(row.arr, set.arr)
+	row.ClearRange(m.cols, row.Size())
+	m.arr[r] = row
+	return m
+}
+
+// Column returns a copy of the specified column as a set of Rows() bits.
+// If the column is outside the matrix, nil is returned.
+func (m *Matrix) Column(c int) *Set {
+	if c < 0 || c >= m.cols {
+		return nil
+	}
+
+	column, _ := NewSet(WithInitialBits(m.rows))
+	for r, row := range m.arr {
+		if row.Get(c) {
+			column.Set(r)
+		}
+	}
+	return column
+}
+
+// Transpose returns a new cols×rows matrix which is the transpose of this matrix.
+// It works on blocks of 64×64 bits.
+func (m *Matrix) Transpose() *Matrix {
+	result, _ := NewMatrix(m.cols, m.rows)
+
+	var block [minBits]uint64
+	for bi := 0; bi < howManyUint64(m.rows); bi++ {
+		for bj := 0; bj < howManyUint64(m.cols); bj++ {
+			for i := range block {
+				block[i] = 0
+				if r := bi*minBits + i; r < m.rows {
+					block[i] = m.arr[r].arr[bj]
+				}
+			}
+
+			transpose64(&block)
+
+			for j := range block {
+				if r := bj*minBits + j; r < m.cols {
+					result.arr[r].arr[bi] = block[j]
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// Mul returns the boolean product of this matrix and the other matrix,
+// in which a bit at (i, j) is true if and only if there is a k that both
+// (i, k) of this matrix and (k, j) of the other matrix are true.
+// The number of columns of this matrix should be equal to the number of
+// rows of the other matrix.
+func (m *Matrix) Mul(other *Matrix) (*Matrix, error) {
+	if m.cols != other.rows {
+		return nil, fmt.Errorf("%w: Matrices have incompatible dimensions", ErrInvalidArgument)
+	}
+
+	result, _ := NewMatrix(m.rows, other.cols)
+	for r, row := range m.arr {
+		row.Iterate(func(k int) bool {
+			result.arr[r].Or(other.arr[k])
+			return true
+		})
+	}
+
+	return result, nil
+}
+
+// Or performs a logical OR of this matrix with the other matrix.
+// Both matrices should have the same dimensions.
+func (m *Matrix) Or(other *Matrix) error {
+	if !m.sameDimensions(other) {
+		return fmt.Errorf("%w: Matrices have different dimensions", ErrInvalidArgument)
+	}
+
+	for r, row := range m.arr {
+		row.Or(other.arr[r])
+	}
+	return nil
+}
+
+// And performs a logical AND of this matrix with the other matrix.
+// Both matrices should have the same dimensions.
+func (m *Matrix) And(other *Matrix) error {
+	if !m.sameDimensions(other) {
+		return fmt.Errorf("%w: Matrices have different dimensions", ErrInvalidArgument)
+	}
+
+	for r, row := range m.arr {
+		row.And(other.arr[r])
+	}
+	return nil
+}
+
+// Equal checks whether both matrices have the same dimensions and bits.
+func (m *Matrix) Equal(other *Matrix) bool {
+	if !m.sameDimensions(other) {
+		return false
+	}
+
+	for r, row := range m.arr {
+		if !row.Equal(other.arr[r]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone creates a new copy of the matrix
+func (m *Matrix) Clone() *Matrix {
+	result := &Matrix{
+		rows: m.rows,
+		cols: m.cols,
+		arr:  make([]*Set, m.rows),
+	}
+	for r, row := range m.arr {
+		result.arr[r] = row.Clone()
+	}
+	return result
+}
+
+// String returns a string representation of this matrix, one line per row
+// with '1' for set bits and '0' for clear bits.
+func (m *Matrix) String() string {
+	b := bytes.Buffer{}
+
+	for r, row := range m.arr {
+		if r > 0 {
+			b.WriteByte('\n')
+		}
+		for c := 0; c < m.cols; c++ {
+			if row.Get(c) {
+				b.WriteByte('1')
+			} else {
+				b.WriteByte('0')
+			}
+		}
+	}
+
+	return b.String()
+}
+
+func (m *Matrix) newRow() *Set {
+	return &Set{
+		arr: make([]uint64, howManyUint64(m.cols)),
+	}
+}
+
+func (m *Matrix) contains(r int, c int) bool {
+	return r >= 0 && r < m.rows && c >= 0 && c < m.cols
+}
+
+func (m *Matrix) sameDimensions(other *Matrix) bool {
+	return m.rows == other.rows && m.cols == other.cols
+}
+
+// transpose64 transposes a 64×64 block of bits in place, where bit j of
+// block[i] is the bit at row i and column j.
+// See "Hacker's Delight" section 7-3.
+func transpose64(block *[minBits]uint64) {
+	mask := uint64(0x00000000FFFFFFFF)
+	for j := uint(32); j != 0; j >>= 1 {
+		for k := uint(0); k < minBits; k = (k + j + 1) &^ j {
+			t := (block[k]>>j ^ block[k+j]) & mask
+			block[k] ^= t << j
+			block[k+j] ^= t
+		}
+		mask ^= mask << (j >> 1)
+	}
+}
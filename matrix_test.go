@@ -0,0 +1,113 @@
+package bit
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMatrix(t *testing.T) {
+	m, err := NewMatrix(3, 70)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, m.Rows())
+	assert.Equal(t, 70, m.Cols())
+
+	_, err = NewMatrix(-1, 3)
+	assert.Error(t, err)
+}
+
+func TestMatrixGetSet(t *testing.T) {
+	m, _ := NewMatrix(3, 70)
+	m.Set(0, 0).Set(1, 69).Set(2, 64)
+
+	assert.True(t, m.Get(0, 0))
+	assert.True(t, m.Get(1, 69))
+	assert.True(t, m.Get(2, 64))
+	assert.False(t, m.Get(1, 68))
+
+	// outside the matrix
+	m.Set(3, 0).Set(0, 70).Set(-1, 0)
+	assert.False(t, m.Get(3, 0))
+	assert.False(t, m.Get(0, 70))
+	assert.False(t, m.Get(-1, 0))
+	assert.Equal(t, 2, len(m.arr[0].arr))
+
+	m.SetValue(1, 69, false)
+	assert.False(t, m.Get(1, 69))
+}
+
+func TestMatrixRowAndColumn(t *testing.T) {
+	m, _ := NewMatrix(3, 4)
+	m.Set(0, 1).Set(1, 1).Set(1, 3)
+
+	assert.True(t, ValueOf([]uint64{10}).Equal(m.Row(1)))
+	assert.True(t, ValueOf([]uint64{3}).Equal(m.Column(1)))
+	assert.Nil(t, m.Row(3))
+	assert.Nil(t, m.Column(4))
+
+	// bits after the last column are dropped
+	m.SetRow(2, ValueOf([]uint64{0xFF}))
+	assert.True(t, ValueOf([]uint64{0xF}).Equal(m.Row(2)))
+	assert.Equal(t, "0100\n0101\n1111", m.String())
+}
+
+func TestMatrixTranspose(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	dimensions := [][2]int{{1, 1}, {3, 5}, {64, 64}, {65, 130}, {200, 7}}
+
+	for _, dimension := range dimensions {
+		m, _ := NewMatrix(dimension[0], dimension[1])
+		for i := 0; i < m.Rows(); i++ {
+			for j := 0; j < m.Cols(); j++ {
+				m.SetValue(i, j, r.Intn(2) == 1)
+			}
+		}
+
+		transposed := m.Transpose()
+		assert.Equal(t, m.Cols(), transposed.Rows())
+		assert.Equal(t, m.Rows(), transposed.Cols())
+		for i := 0; i < m.Rows(); i++ {
+			for j := 0; j < m.Cols(); j++ {
+				assert.Equal(t, m.Get(i, j), transposed.Get(j, i))
+			}
+		}
+		assert.True(t, m.Equal(transposed.Transpose()))
+	}
+}
+
+func TestMatrixMul(t *testing.T) {
+	a, _ := NewMatrix(2, 3)
+	a.Set(0, 0).Set(0, 2).Set(1, 1)
+
+	b, _ := NewMatrix(3, 2)
+	b.Set(0, 1).Set(2, 0)
+
+	result, err := a.Mul(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "11\n00", result.String())
+
+	_, err = a.Mul(a)
+	assert.Error(t, err)
+}
+
+func TestMatrixOrAnd(t *testing.T) {
+	a, _ := NewMatrix(2, 2)
+	a.Set(0, 0).Set(1, 1)
+
+	b, _ := NewMatrix(2, 2)
+	b.Set(0, 0).Set(0, 1)
+
+	or := a.Clone()
+	assert.NoError(t, or.Or(b))
+	assert.Equal(t, "11\n01", or.String())
+
+	and := a.Clone()
+	assert.NoError(t, and.And(b))
+	assert.Equal(t, "10\n00", and.String())
+
+	c, _ := NewMatrix(2, 3)
+	assert.Error(t, a.Or(c))
+	assert.Error(t, a.And(c))
+	assert.False(t, a.Equal(c))
+}
@@ -1,9 +1,28 @@
 package bit
 
+// BoundPolicy determines what happens on writes past the bound of
+// a set with a limited number of bits.
+type BoundPolicy int
+
+const (
+	// IgnoreOutOfBounds ignores the writes past the bound
+	IgnoreOutOfBounds BoundPolicy = iota
+	// PanicOutOfBounds panics on the writes past the bound
+	PanicOutOfBounds
+)
+
 // Options need for set initialization
 type Options struct {
 	// number of initial bits
 	nbits int
+	// maximum number of bits, zero means unlimited
+	maxBits int
+	// what to do on writes past maxBits
+	policy BoundPolicy
+	// whether misuse panics instead of being ignored
+	strict bool
+	// unused bits after which the set is compacted on clears, zero means never
+	shrinkBits int
 }
 
 type Option func(*Options)
@@ -14,3 +33,47 @@ func WithInitialBits(n int) Option {
 		opts.nbits = n
 	}
 }
+
+// WithMaxBits limits the set to n bits, so it never grows past index n-1.
+// Zero means unlimited, which is the default.
+func WithMaxBits(n int) Option {
+	return func(opts *Options) {
+		opts.maxBits = n
+	}
+}
+
+// WithFixedSize allocates n bits up front and limits the set to them
+func WithFixedSize(n int) Option {
+	return func(opts *Options) {
+		opts.nbits = n
+		opts.maxBits = n
+	}
+}
+
+// WithBoundPolicy sets what happens on writes past the bound of the set.
+// The default is IgnoreOutOfBounds.
+func WithBoundPolicy(policy BoundPolicy) Option {
+	return func(opts *Options) {
+		opts.policy = policy
+	}
+}
+
+// WithStrictMode makes the set panic on misuse, such as negative indices or
+// reversed ranges, instead of ignoring it. The panic value is an error
+// wrapping one of the errors of this package, like ErrNegativeIndex.
+func WithStrictMode() Option {
+	return func(opts *Options) {
+		opts.strict = true
+	}
+}
+
+// WithAutoShrink makes the set compact itself when at least n bits of reserved
+// memory are unused after ClearAll, ClearRange, And, DeleteRange or ShiftRight,
+// so long-lived sets don't keep the memory of transient spikes.
+// Zero means never, which is the default.
+// Like Compact, it never shrinks the set below the bits reserved by WithInitialBits.
+func WithAutoShrink(n int) Option {
+	return func(opts *Options) {
+		opts.shrinkBits = n
+	}
+}
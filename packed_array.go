@@ -0,0 +1,167 @@
+package bit
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// PackedArray is an array of unsigned integers of a fixed width between 1 and
+// 64 bits, packed without any padding in the words of a Set.
+// The i-th element occupies the bits from i*Width() to (i+1)*Width()-1.
+type PackedArray struct {
+	width int
+	n     int
+	set   *Set
+}
+
+// NewPackedArray creates an array of n elements of width bits,
+// all initially zero.
+func NewPackedArray(width int, n int) (*PackedArray, error) {
+	if width < 1 || width > minBits {
+		return nil, fmt.Errorf("%w: Width should be between 1 and 64", ErrInvalidArgument)
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("%w: Number of elements is negative", ErrInvalidArgument)
+	}
+
+	set, err := NewSet(WithInitialBits(width * n))
+	if err != nil {
+		return nil, err
+	}
+
+	return &PackedArray{
+		width: width,
+		n:     n,
+		set:   set,
+	}, nil
+}
+
+// Len returns the number of elements.
+func (a *PackedArray) Len() int {
+	return a.n
+}
+
+// Width returns the number of bits of each element.
+func (a *PackedArray) Width() int {
+	return a.width
+}
+
+// Get returns the i-th element.
+// It panics if the index is out of range.
+func (a *PackedArray) Get(i int) uint64 {
+	a.checkIndex(i)
+	return readBits(a.set.arr, i*a.width, a.width)
+}
+
+// Set sets the i-th element to the lowest Width() bits of the value.
+// It panics if the index is out of range.
+func (a *PackedArray) Set(i int, value uint64) *PackedArray {
+	a.checkIndex(i)
+	writeBits(a.set.arr, i*a.width, a.width, value)
+	return a
+}
+
+// Append adds the lowest Width() bits of the value as the last element,
+// growing the array as needed.
+func (a *PackedArray) Append(value uint64) *PackedArray {
+	a.set.SetBits(a.n*a.width, a.width, value)
+	a.n++
+	return a
+}
+
+// Fill sets all the elements to the lowest Width() bits of the value.
+// The bit pattern of the elements repeats every Width()/gcd(Width(), 64)
+// words, so only the first period is computed and the rest is copied.
+func (a *PackedArray) Fill(value uint64) *PackedArray {
+	nbits := a.n * a.width
+	if nbits == 0 {
+		return a
+	}
+
+	nwords := howManyUint64(nbits)
+	period := a.width / gcd(a.width, minBits)
+	if period > nwords {
+		period = nwords
+	}
+
+	for i := 0; i*a.width < period*minBits && i < a.n; i++ {
+		writeBits(a.set.arr, i*a.width, min(a.width, period*minBits-i*a.width), value)
+	}
+	for i := period; i < nwords; i += period {
+		copy(a.set.arr[i:nwords], a.set.arr[:period])
+	}
+
+	// clear the bits after the last element
+	if rem := nbits % minBits; rem != 0 {
+		a.set.arr[nwords-1] &= 1<<uint(rem) - 1
+	}
+	return a
+}
+
+// Iterate calls fn for every element in order, until fn returns false.
+func (a *PackedArray) Iterate(fn func(index int, value uint64) bool) {
+	for i := 0; i < a.n; i++ {
+		if !fn(i, readBits(a.set.arr, i*a.width, a.width)) {
+			return
+		}
+	}
+}
+
+// Values returns all the elements.
+func (a *PackedArray) Values() []uint64 {
+	values := make([]uint64, a.n)
+	for i := range values {
+		values[i] = readBits(a.set.arr, i*a.width, a.width)
+	}
+	return values
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The format is the width and the number of elements as little endian
+// uint64, followed by the ceil(width*n/64) words holding the elements as
+// little endian uint64.
+func (a *PackedArray) MarshalBinary() ([]byte, error) {
+	nwords := howManyUint64(a.n * a.width)
+
+	data := make([]byte, 16, 16+nwords*8)
+	binary.LittleEndian.PutUint64(data[0:8], uint64(a.width))
+	binary.LittleEndian.PutUint64(data[8:16], uint64(a.n))
+
+	return append(data, a.set.Bytes()[:nwords*8]...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (a *PackedArray) UnmarshalBinary(data []byte) error {
+	if len(data) < 16 {
+		return fmt.Errorf("%w: Packed array data is too short", ErrCorruptData)
+	}
+
+	width := binary.LittleEndian.Uint64(data[0:8])
+	n := binary.LittleEndian.Uint64(data[8:16])
+	if width < 1 || width > minBits || n > uint64(len(data))*8 {
+		return fmt.Errorf("%w: Packed array data", ErrCorruptData)
+	}
+
+	payload := data[16:]
+	if len(payload) != howManyUint64(int(width*n))*8 {
+		return fmt.Errorf("%w: Packed array data", ErrCorruptData)
+	}
+
+	a.width = int(width)
+	a.n = int(n)
+	a.set = FromByteArray(payload)
+	return nil
+}
+
+func (a *PackedArray) checkIndex(i int) {
+	if i < 0 || i >= a.n {
+		panic(fmt.Errorf("%w: packed array index %d", ErrOutOfBounds, i))
+	}
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,126 @@
+package bit
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPackedArray(t *testing.T) {
+	testCases := []struct {
+		width    int
+		n        int
+		hasError bool
+	}{
+		{width: 5, n: 100, hasError: false},
+		{width: 64, n: 0, hasError: false},
+		{width: 0, n: 10, hasError: true},
+		{width: 65, n: 10, hasError: true},
+		{width: 5, n: -1, hasError: true},
+	}
+
+	for _, test := range testCases {
+		a, err := NewPackedArray(test.width, test.n)
+		if test.hasError {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, test.n, a.Len())
+		assert.Equal(t, test.width, a.Width())
+	}
+}
+
+func TestPackedArrayGetSet(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+
+	for _, width := range []int{1, 5, 7, 13, 32, 63, 64} {
+		a, _ := NewPackedArray(width, 100)
+		expected := make([]uint64, 100)
+		for i := range expected {
+			expected[i] = r.Uint64()
+			if width < 64 {
+				expected[i] &= 1<<uint(width) - 1
+			}
+			a.Set(i, expected[i])
+		}
+
+		for i := range expected {
+			assert.Equal(t, expected[i], a.Get(i))
+		}
+		assert.Equal(t, expected, a.Values())
+	}
+
+	a, _ := NewPackedArray(5, 3)
+	a.Set(1, 0xFF)
+	assert.Equal(t, uint64(0x1F), a.Get(1))
+	assert.Equal(t, uint64(0), a.Get(0))
+	assert.Equal(t, uint64(0), a.Get(2))
+
+	assert.Panics(t, func() { a.Get(3) })
+	assert.Panics(t, func() { a.Set(-1, 0) })
+}
+
+func TestPackedArrayAppend(t *testing.T) {
+	a, _ := NewPackedArray(12, 0)
+	for i := 0; i < 20; i++ {
+		a.Append(uint64(i * 100))
+	}
+
+	assert.Equal(t, 20, a.Len())
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, uint64(i*100), a.Get(i))
+	}
+	assert.Equal(t, 4, len(a.set.arr))
+}
+
+func TestPackedArrayFill(t *testing.T) {
+	for _, width := range []int{1, 3, 5, 8, 12, 24, 63, 64} {
+		for _, n := range []int{0, 1, 10, 100, 1000} {
+			a, _ := NewPackedArray(width, n)
+			value := uint64(0xDEADBEEFCAFEBABE)
+			a.Fill(value)
+
+			if width < 64 {
+				value &= 1<<uint(width) - 1
+			}
+			for i := 0; i < n; i++ {
+				assert.Equal(t, value, a.Get(i))
+			}
+			// no bits after the last element
+			assert.True(t, a.set.Length() <= n*width)
+		}
+	}
+}
+
+func TestPackedArrayIterate(t *testing.T) {
+	a, _ := NewPackedArray(4, 0)
+	a.Append(1).Append(2).Append(3).Append(4)
+
+	visited := make([]uint64, 0)
+	a.Iterate(func(index int, value uint64) bool {
+		visited = append(visited, value)
+		return index < 2
+	})
+	assert.Equal(t, []uint64{1, 2, 3}, visited)
+}
+
+func TestPackedArrayMarshalBinary(t *testing.T) {
+	a, _ := NewPackedArray(5, 0)
+	for i := 0; i < 30; i++ {
+		a.Append(uint64(i))
+	}
+
+	data, err := a.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, 16+3*8, len(data))
+
+	decoded := &PackedArray{}
+	assert.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, a.Values(), decoded.Values())
+	assert.Equal(t, 5, decoded.Width())
+
+	assert.ErrorIs(t, decoded.UnmarshalBinary(data[:10]), ErrCorruptData)
+	assert.ErrorIs(t, decoded.UnmarshalBinary(data[:len(data)-8]), ErrCorruptData)
+}
@@ -0,0 +1,122 @@
+package bit
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"math/rand"
+	"sort"
+)
+
+// RandomSetBit returns the index of a bit set to true chosen uniformly at
+// random, or -1 if there is no set bit. It runs in O(words), or in
+// O(log n) when the rank and select index is built.
+func (set *Set) RandomSetBit(r *rand.Rand) int {
+	ones := set.Cardinality()
+	if ones == 0 {
+		return -1
+	}
+
+	return set.Select1(r.Intn(ones))
+}
+
+// RandomClearBit returns the index of a bit set to false chosen uniformly at
+// random, or -1 if there is no clear bit. The bit is chosen below the bound of
+// the set, or below Size() if the set is unlimited. It runs in O(words), or in
+// O(log n) when the rank and select index is built.
+func (set *Set) RandomClearBit(r *rand.Rand) int {
+	limit := set.Size()
+	if set.maxBits > 0 {
+		limit = set.maxBits
+	}
+
+	// all the set bits are below the limit
+	zeros := limit - set.Cardinality()
+	if zeros <= 0 {
+		return -1
+	}
+
+	return set.Select0(r.Intn(zeros))
+}
+
+// Sample returns k distinct indices of set bits in ascending order, chosen
+// uniformly at random among all such subsets. If the set has less than k set
+// bits, all of them are returned. If k is negative nothing is returned, or it
+// panics in strict mode.
+// It runs in O(words + k log k).
+func (set *Set) Sample(k int, r *rand.Rand) []int {
+	if k < 0 {
+		set.misuse(fmt.Errorf("%w: Number of samples is negative: %d", ErrInvalidArgument, k))
+		return nil
+	}
+
+	ones := set.Cardinality()
+	if k >= ones {
+		return set.ToIndices(nil)
+	}
+
+	// choose k distinct ranks with Floyd's algorithm
+	chosen := make(map[int]struct{}, k)
+	ranks := make([]int, 0, k)
+	for j := ones - k; j < ones; j++ {
+		rank := r.Intn(j + 1)
+		if _, ok := chosen[rank]; ok {
+			rank = j
+		}
+		chosen[rank] = struct{}{}
+		ranks = append(ranks, rank)
+	}
+	sort.Ints(ranks)
+
+	// and map them to indices in a single pass over the words
+	indices := ranks
+	before := 0
+	i := 0
+	for arrIndex, word := range set.arr {
+		count := bits.OnesCount64(word)
+		for ; i < len(ranks) && ranks[i] < before+count; i++ {
+			indices[i] = arrIndex*minBits + selectInWord(word, ranks[i]-before)
+		}
+		before += count
+	}
+
+	return indices
+}
+
+// RandomSet returns a new set of n bits where every bit is set to true
+// independently with probability p. It jumps from one set bit to the next
+// with geometrically distributed skips, so it runs in O(words + n*p).
+func RandomSet(n int, p float64, r *rand.Rand) (*Set, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("%w: Number of bits is negative", ErrInvalidArgument)
+	}
+	if p < 0 || p > 1 || math.IsNaN(p) {
+		return nil, fmt.Errorf("%w: Probability should be between 0 and 1", ErrInvalidArgument)
+	}
+
+	set, _ := NewSet(WithInitialBits(n))
+	if p == 0 {
+		return set, nil
+	}
+	if p == 1 {
+		for i := 0; i < n; i += minBits {
+			writeBits(set.arr, i, min(minBits, n-i), ^uint64(0))
+		}
+		return set, nil
+	}
+
+	// the number of clear bits before the next set bit is geometric:
+	// P(skip = s) = (1-p)^s * p
+	logq := math.Log1p(-p)
+	for i := -1; ; {
+		skip := math.Floor(math.Log(1-r.Float64()) / logq)
+		if skip >= float64(n-i-1) {
+			break
+		}
+
+		i += int(skip) + 1
+		set.arr[i/minBits] |= 1 << uint(i%minBits)
+	}
+
+	return set, nil
+}
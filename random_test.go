@@ -0,0 +1,110 @@
+package bit
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomSetBit(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	set, _ := FromIndices([]int{3, 64, 65, 200})
+
+	counts := make(map[int]int)
+	for i := 0; i < 4000; i++ {
+		counts[set.RandomSetBit(r)]++
+	}
+	assert.Equal(t, 4, len(counts))
+	for _, index := range []int{3, 64, 65, 200} {
+		assert.InDelta(t, 1000, counts[index], 150, "index %d", index)
+	}
+
+	set.BuildRankSelect()
+	assert.True(t, set.Get(set.RandomSetBit(r)))
+
+	empty, _ := NewSet()
+	assert.Equal(t, -1, empty.RandomSetBit(r))
+}
+
+func TestRandomClearBit(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	set, _ := NewSet(WithFixedSize(100))
+	set.SetRange(0, 100).Clear(7).Clear(70).Clear(99)
+
+	counts := make(map[int]int)
+	for i := 0; i < 3000; i++ {
+		counts[set.RandomClearBit(r)]++
+	}
+	assert.Equal(t, 3, len(counts))
+	for _, index := range []int{7, 70, 99} {
+		assert.InDelta(t, 1000, counts[index], 150, "index %d", index)
+	}
+
+	set.Set(7).Set(70).Set(99)
+	assert.Equal(t, -1, set.RandomClearBit(r))
+
+	unbounded, _ := NewSet()
+	unbounded.SetRange(0, 63)
+	assert.Equal(t, 63, unbounded.RandomClearBit(r))
+	unbounded.Set(63)
+	assert.Equal(t, -1, unbounded.RandomClearBit(r))
+}
+
+func TestSample(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	set, _ := NewSet()
+	for i := 0; i < 1000; i += 3 {
+		set.Set(i)
+	}
+
+	sample := set.Sample(50, r)
+	assert.Equal(t, 50, len(sample))
+	assert.True(t, sort.IntsAreSorted(sample))
+	for i, index := range sample {
+		assert.True(t, set.Get(index))
+		if i > 0 {
+			assert.NotEqual(t, sample[i-1], index)
+		}
+	}
+
+	// every set bit is equally likely
+	counts := make(map[int]int)
+	small, _ := FromIndices([]int{1, 10, 100, 130})
+	for i := 0; i < 3000; i++ {
+		for _, index := range small.Sample(2, r) {
+			counts[index]++
+		}
+	}
+	for _, index := range []int{1, 10, 100, 130} {
+		assert.InDelta(t, 1500, counts[index], 150, "index %d", index)
+	}
+
+	assert.Equal(t, []int{1, 10, 100, 130}, small.Sample(10, r))
+	assert.Equal(t, []int{}, small.Sample(0, r))
+	assert.Nil(t, small.Sample(-1, r))
+}
+
+func TestRandomSet(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	set, err := RandomSet(100000, 0.1, r)
+	assert.NoError(t, err)
+	assert.InDelta(t, 10000, set.Cardinality(), 400)
+	assert.Less(t, set.Length(), 100001)
+
+	set, err = RandomSet(130, 1, r)
+	assert.NoError(t, err)
+	assert.Equal(t, 130, set.Cardinality())
+	assert.Equal(t, 130, set.Length())
+
+	set, err = RandomSet(130, 0, r)
+	assert.NoError(t, err)
+	assert.True(t, set.IsEmpty())
+
+	_, err = RandomSet(-1, 0.5, r)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = RandomSet(10, 1.5, r)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
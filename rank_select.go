@@ -0,0 +1,194 @@
+package bit
+
+import (
+	"math/bits"
+	"sort"
+)
+
+const (
+	// number of words covered by each superblock of the rank index
+	wordsPerSuperblock = 8
+	// number of bits covered by each superblock of the rank index
+	superblockBits = wordsPerSuperblock * minBits
+)
+
+// rankIndex is the auxiliary index used by rank and select queries.
+// It stores the number of set bits before every superblock, and the number
+// of set bits before every word within its superblock.
+type rankIndex struct {
+	superblocks []int
+	blocks      []uint16
+	ones        int // total number of set bits
+}
+
+// BuildRankSelect builds the auxiliary index that makes Rank1 and Rank0
+// run in O(1) and Select1 and Select0 run in O(log n).
+// The index is dropped on any modification of the set, so it should be
+// built again after the set has changed. Without the index all these
+// methods still work, but in linear time.
+func (set *Set) BuildRankSelect() *Set {
+	nwords := len(set.arr)
+	index := &rankIndex{
+		superblocks: make([]int, (nwords+wordsPerSuperblock-1)/wordsPerSuperblock+1),
+		blocks:      make([]uint16, nwords),
+	}
+
+	total := 0
+	inSuperblock := 0
+	for i, word := range set.arr {
+		if i%wordsPerSuperblock == 0 {
+			index.superblocks[i/wordsPerSuperblock] = total
+			inSuperblock = 0
+		}
+		index.blocks[i] = uint16(inSuperblock)
+
+		count := bits.OnesCount64(word)
+		inSuperblock += count
+		total += count
+	}
+	index.superblocks[len(index.superblocks)-1] = total
+	index.ones = total
+
+	set.rank = index
+	return set
+}
+
+// HasRankSelect returns true if the rank and select index is built and
+// still valid.
+func (set *Set) HasRankSelect() bool {
+	return set.rank != nil
+}
+
+// Rank1 returns the number of bits set to true before the specified index
+// (exclusive).
+func (set *Set) Rank1(index int) int {
+	if index <= 0 {
+		return 0
+	}
+	if index > set.Size() {
+		index = set.Size()
+	}
+
+	arrIndex, bitIndex := index/minBits, index%minBits
+
+	count := 0
+	if set.rank != nil {
+		count = set.rank.superblocks[arrIndex/wordsPerSuperblock]
+		if arrIndex < len(set.arr) {
+			count += int(set.rank.blocks[arrIndex])
+		} else {
+			// index is at the end of the set
+			return set.rank.ones
+		}
+	} else {
+		for i := 0; i < arrIndex; i++ {
+			count += bits.OnesCount64(set.arr[i])
+		}
+	}
+
+	if bitIndex > 0 {
+		count += bits.OnesCount64(set.arr[arrIndex] & (1<<uint(bitIndex) - 1))
+	}
+
+	return count
+}
+
+// Rank0 returns the number of bits set to false before the specified index
+// (exclusive).
+func (set *Set) Rank0(index int) int {
+	if index <= 0 {
+		return 0
+	}
+
+	return index - set.Rank1(index)
+}
+
+// Select1 returns the index of the k-th bit set to true, counting from zero,
+// so that Rank1(Select1(k)) == k.
+// If no such bit exists, or k is negative, then -1 is returned.
+func (set *Set) Select1(k int) int {
+	if k < 0 {
+		return -1
+	}
+
+	arrIndex, remaining := set.selectWord(k, true)
+	if arrIndex == -1 {
+		return -1
+	}
+
+	return arrIndex*minBits + selectInWord(set.arr[arrIndex], remaining)
+}
+
+// Select0 returns the index of the k-th bit set to false, counting from zero,
+// so that Rank0(Select0(k)) == k.
+// As all the bits outside the boundary are false, an index greater than or
+// equal to Size() is returned when the set has not enough clear bits.
+// If k is negative, then -1 is returned.
+func (set *Set) Select0(k int) int {
+	if k < 0 {
+		return -1
+	}
+
+	arrIndex, remaining := set.selectWord(k, false)
+	if arrIndex == -1 {
+		// all is clear outside boundary
+		return set.Size() + remaining
+	}
+
+	return arrIndex*minBits + selectInWord(^set.arr[arrIndex], remaining)
+}
+
+// selectWord finds the word containing the k-th bit with the specified value,
+// and returns the index of the word and the rank of the bit within it.
+// If there is no such word, -1 and the number of remaining bits is returned.
+func (set *Set) selectWord(k int, value bool) (arrIndex int, remaining int) {
+	count := func(word uint64) int {
+		if value {
+			return bits.OnesCount64(word)
+		}
+		return minBits - bits.OnesCount64(word)
+	}
+
+	start := 0
+	if set.rank != nil {
+		superblocks := set.rank.superblocks
+		// number of bits with the specified value before the superblock
+		before := func(i int) int {
+			if value {
+				return superblocks[i]
+			}
+			return min(i*superblockBits, set.Size()) - superblocks[i]
+		}
+
+		nsuperblocks := len(superblocks) - 1
+		if k >= before(nsuperblocks) {
+			return -1, k - before(nsuperblocks)
+		}
+
+		// first superblock that starts after the k-th bit
+		i := sort.Search(nsuperblocks, func(i int) bool {
+			return before(i) > k
+		})
+		start = (i - 1) * wordsPerSuperblock
+		k -= before(i - 1)
+	}
+
+	for i := start; i < len(set.arr); i++ {
+		c := count(set.arr[i])
+		if k < c {
+			return i, k
+		}
+		k -= c
+	}
+
+	return -1, k
+}
+
+// selectInWord returns the position of the k-th set bit of the word
+func selectInWord(word uint64, k int) int {
+	for i := 0; i < k; i++ {
+		word &= word - 1 // clear the lowest set bit
+	}
+
+	return bits.TrailingZeros64(word)
+}
@@ -0,0 +1,106 @@
+package bit
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRank(t *testing.T) {
+	testCases := []struct {
+		set   *Set
+		index int
+		rank1 int
+	}{
+		{set: ValueOf([]uint64{0}), index: 10, rank1: 0},
+		{set: ValueOf([]uint64{15}), index: -1, rank1: 0},
+		{set: ValueOf([]uint64{15}), index: 0, rank1: 0},
+		{set: ValueOf([]uint64{15}), index: 2, rank1: 2},
+		{set: ValueOf([]uint64{15}), index: 64, rank1: 4},
+		{set: ValueOf([]uint64{15, 1}), index: 64, rank1: 4},
+		{set: ValueOf([]uint64{15, 1}), index: 65, rank1: 5},
+		{set: ValueOf([]uint64{15, 1}), index: 1000, rank1: 5},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.rank1, test.set.Rank1(test.index))
+		test.set.BuildRankSelect()
+		assert.Equal(t, test.rank1, test.set.Rank1(test.index))
+		if test.index > 0 {
+			assert.Equal(t, test.index-test.rank1, test.set.Rank0(test.index))
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	testCases := []struct {
+		set     *Set
+		k       int
+		select1 int
+		select0 int
+	}{
+		{set: ValueOf([]uint64{0}), k: 0, select1: -1, select0: 0},
+		{set: ValueOf([]uint64{15}), k: -1, select1: -1, select0: -1},
+		{set: ValueOf([]uint64{15}), k: 0, select1: 0, select0: 4},
+		{set: ValueOf([]uint64{15}), k: 3, select1: 3, select0: 7},
+		{set: ValueOf([]uint64{15}), k: 4, select1: -1, select0: 8},
+		{set: ValueOf([]uint64{15, 2}), k: 4, select1: 65, select0: 8},
+		{set: ValueOf([]uint64{^uint64(0)}), k: 0, select1: 0, select0: 64},
+		{set: ValueOf([]uint64{^uint64(0)}), k: 2, select1: 2, select0: 66},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.select1, test.set.Select1(test.k))
+		assert.Equal(t, test.select0, test.set.Select0(test.k))
+		test.set.BuildRankSelect()
+		assert.Equal(t, test.select1, test.set.Select1(test.k))
+		assert.Equal(t, test.select0, test.set.Select0(test.k))
+	}
+}
+
+func TestRankSelectRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	for _, nwords := range []int{1, 7, 8, 9, 33} {
+		arr := make([]uint64, nwords)
+		for i := range arr {
+			arr[i] = r.Uint64() & r.Uint64()
+		}
+		set := ValueOf(arr).BuildRankSelect()
+
+		ones, zeros := 0, 0
+		for i := 0; i < set.Size(); i++ {
+			assert.Equal(t, ones, set.Rank1(i))
+			assert.Equal(t, zeros, set.Rank0(i))
+			if set.Get(i) {
+				assert.Equal(t, i, set.Select1(ones))
+				ones++
+			} else {
+				assert.Equal(t, i, set.Select0(zeros))
+				zeros++
+			}
+		}
+		assert.Equal(t, -1, set.Select1(ones))
+		assert.Equal(t, set.Size(), set.Select0(zeros))
+		assert.Equal(t, ones, set.Cardinality())
+	}
+}
+
+func TestRankSelectInvalidation(t *testing.T) {
+	set := ValueOf([]uint64{15}).BuildRankSelect()
+	assert.True(t, set.HasRankSelect())
+
+	set.Set(100)
+	assert.False(t, set.HasRankSelect())
+	assert.Equal(t, 5, set.Rank1(101))
+	assert.Equal(t, 100, set.Select1(4))
+
+	set.BuildRankSelect()
+	set.Clear(100)
+	assert.False(t, set.HasRankSelect())
+
+	set.BuildRankSelect()
+	set.Or(ValueOf([]uint64{16}))
+	assert.False(t, set.HasRankSelect())
+	assert.Equal(t, 4, set.Select1(4))
+}
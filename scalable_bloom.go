@@ -0,0 +1,211 @@
+package bit
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+const (
+	// each new filter of the scalable Bloom filter holds this times
+	// more elements than the previous one
+	scalableGrowth = 2
+)
+
+// ScalableBloomFilter is a Bloom filter that grows as needed. It chains
+// Bloom filters, and when the current one is full a new one with a larger
+// capacity and a tighter false positive rate is added, so the overall false
+// positive rate stays below the requested one.
+// See "Scalable Bloom Filters" by Almeida, Baquero, Preguiça and Hutchison.
+type ScalableBloomFilter struct {
+	n       int     // capacity of the first filter
+	fpRate  float64 // overall false positive rate
+	ratio   float64 // tightening ratio of the false positive rate
+	filters []*BloomFilter
+	counts  []int // number of elements added to each filter
+}
+
+// NewScalableBloomFilter creates a scalable Bloom filter whose first filter
+// holds n elements. fpRate is the overall false positive rate, and ratio
+// (between 0 and 1, typically 0.8 or 0.9) is the factor by which the false
+// positive rate of every new filter is tightened.
+func NewScalableBloomFilter(n int, fpRate float64, ratio float64) (*ScalableBloomFilter, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: Capacity should be positive", ErrInvalidArgument)
+	}
+	if fpRate <= 0 || fpRate >= 1 {
+		return nil, fmt.Errorf("%w: False positive rate should be between 0 and 1", ErrInvalidArgument)
+	}
+	if ratio <= 0 || ratio >= 1 {
+		return nil, fmt.Errorf("%w: Tightening ratio should be between 0 and 1", ErrInvalidArgument)
+	}
+
+	f := &ScalableBloomFilter{
+		n:      n,
+		fpRate: fpRate,
+		ratio:  ratio,
+	}
+	f.addFilter()
+
+	return f, nil
+}
+
+// Count returns the number of distinct elements added to the filter.
+// Elements that were reported as present when added are not counted.
+func (f *ScalableBloomFilter) Count() int {
+	total := 0
+	for _, count := range f.counts {
+		total += count
+	}
+	return total
+}
+
+// FilterCount returns the number of chained Bloom filters.
+func (f *ScalableBloomFilter) FilterCount() int {
+	return len(f.filters)
+}
+
+// Add adds the data to the filter.
+func (f *ScalableBloomFilter) Add(data []byte) *ScalableBloomFilter {
+	f.TestAndAdd(data)
+	return f
+}
+
+// Test returns true if the data is possibly in the filter, and false if
+// it is definitely not.
+func (f *ScalableBloomFilter) Test(data []byte) bool {
+	for i := len(f.filters) - 1; i >= 0; i-- {
+		if f.filters[i].Test(data) {
+			return true
+		}
+	}
+	return false
+}
+
+// TestAndAdd reports whether the data was possibly in the filter
+// (same as Test) and adds it to the filter if it was not.
+func (f *ScalableBloomFilter) TestAndAdd(data []byte) bool {
+	if f.Test(data) {
+		return true
+	}
+
+	last := len(f.filters) - 1
+	if f.counts[last] >= f.capacity(last) {
+		f.addFilter()
+		last++
+	}
+
+	f.filters[last].Add(data)
+	f.counts[last]++
+	return false
+}
+
+// ClearAll removes all the elements from the filter and drops
+// all the filters except the first one.
+func (f *ScalableBloomFilter) ClearAll() *ScalableBloomFilter {
+	f.filters = f.filters[:1]
+	f.counts = f.counts[:1]
+	f.filters[0].ClearAll()
+	f.counts[0] = 0
+	return f
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// All numbers are little endian. The format is:
+//   - capacity of the first filter as uint64
+//   - false positive rate as float64 bits
+//   - tightening ratio as float64 bits
+//   - number of filters as uint64
+//   - for every filter: number of elements as uint64, length of the
+//     encoded filter as uint64 and the filter encoded by BloomFilter.MarshalBinary
+func (f *ScalableBloomFilter) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 32)
+	binary.LittleEndian.PutUint64(data[0:8], uint64(f.n))
+	binary.LittleEndian.PutUint64(data[8:16], math.Float64bits(f.fpRate))
+	binary.LittleEndian.PutUint64(data[16:24], math.Float64bits(f.ratio))
+	binary.LittleEndian.PutUint64(data[24:32], uint64(len(f.filters)))
+
+	for i, filter := range f.filters {
+		encoded, err := filter.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+
+		header := make([]byte, 16)
+		binary.LittleEndian.PutUint64(header[0:8], uint64(f.counts[i]))
+		binary.LittleEndian.PutUint64(header[8:16], uint64(len(encoded)))
+
+		data = append(data, header...)
+		data = append(data, encoded...)
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (f *ScalableBloomFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < 32 {
+		return fmt.Errorf("%w: Scalable Bloom filter data is too short", ErrCorruptData)
+	}
+
+	n := int(binary.LittleEndian.Uint64(data[0:8]))
+	fpRate := math.Float64frombits(binary.LittleEndian.Uint64(data[8:16]))
+	ratio := math.Float64frombits(binary.LittleEndian.Uint64(data[16:24]))
+	nfilters := binary.LittleEndian.Uint64(data[24:32])
+	data = data[32:]
+
+	if n <= 0 || !(fpRate > 0 && fpRate < 1) || !(ratio > 0 && ratio < 1) || nfilters == 0 {
+		return fmt.Errorf("%w: Scalable Bloom filter data", ErrCorruptData)
+	}
+
+	filters := make([]*BloomFilter, 0)
+	counts := make([]int, 0)
+	for i := uint64(0); i < nfilters; i++ {
+		if len(data) < 16 {
+			return fmt.Errorf("%w: Scalable Bloom filter data", ErrCorruptData)
+		}
+
+		count := int(binary.LittleEndian.Uint64(data[0:8]))
+		length := binary.LittleEndian.Uint64(data[8:16])
+		data = data[16:]
+		if length > uint64(len(data)) {
+			return fmt.Errorf("%w: Scalable Bloom filter data", ErrCorruptData)
+		}
+
+		filter := &BloomFilter{}
+		if err := filter.UnmarshalBinary(data[:length]); err != nil {
+			return err
+		}
+		data = data[length:]
+
+		filters = append(filters, filter)
+		counts = append(counts, count)
+	}
+
+	if len(data) != 0 {
+		return fmt.Errorf("%w: Scalable Bloom filter data", ErrCorruptData)
+	}
+
+	f.n = n
+	f.fpRate = fpRate
+	f.ratio = ratio
+	f.filters = filters
+	f.counts = counts
+	return nil
+}
+
+// capacity returns the number of elements the i-th filter can hold
+func (f *ScalableBloomFilter) capacity(i int) int {
+	return f.n * int(math.Pow(scalableGrowth, float64(i)))
+}
+
+// addFilter appends a new filter. The false positive rate of the i-th filter
+// is fpRate * (1 - ratio) * ratio^i, so the compounded rate converges to fpRate.
+func (f *ScalableBloomFilter) addFilter() {
+	i := len(f.filters)
+	fpRate := f.fpRate * (1 - f.ratio) * math.Pow(f.ratio, float64(i))
+
+	filter, _ := NewBloomFilterWithEstimates(f.capacity(i), fpRate)
+	f.filters = append(f.filters, filter)
+	f.counts = append(f.counts, 0)
+}
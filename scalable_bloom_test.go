@@ -0,0 +1,126 @@
+package bit
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewScalableBloomFilter(t *testing.T) {
+	testCases := []struct {
+		n        int
+		fpRate   float64
+		ratio    float64
+		hasError bool
+	}{
+		{n: 100, fpRate: 0.01, ratio: 0.9, hasError: false},
+		{n: 0, fpRate: 0.01, ratio: 0.9, hasError: true},
+		{n: 100, fpRate: 0, ratio: 0.9, hasError: true},
+		{n: 100, fpRate: 1, ratio: 0.9, hasError: true},
+		{n: 100, fpRate: 0.01, ratio: 0, hasError: true},
+		{n: 100, fpRate: 0.01, ratio: 1, hasError: true},
+	}
+
+	for _, test := range testCases {
+		_, err := NewScalableBloomFilter(test.n, test.fpRate, test.ratio)
+		if test.hasError {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
+
+func TestScalableBloomFilterGrowth(t *testing.T) {
+	f, _ := NewScalableBloomFilter(100, 0.01, 0.9)
+	assert.Equal(t, 1, f.FilterCount())
+
+	key := make([]byte, 8)
+	next := 0
+	addUntil := func(count int) {
+		for f.Count() < count {
+			binary.LittleEndian.PutUint64(key, uint64(next))
+			f.Add(key)
+			next++
+		}
+	}
+
+	addUntil(100)
+	assert.Equal(t, 1, f.FilterCount())
+
+	// 100 + 200 + 400
+	addUntil(700)
+	assert.Equal(t, 3, f.FilterCount())
+
+	addUntil(701)
+	assert.Equal(t, 4, f.FilterCount())
+
+	// no false negatives
+	for i := 0; i < next; i++ {
+		binary.LittleEndian.PutUint64(key, uint64(i))
+		assert.True(t, f.Test(key))
+	}
+
+	f.ClearAll()
+	assert.Equal(t, 1, f.FilterCount())
+	assert.Equal(t, 0, f.Count())
+}
+
+func TestScalableBloomFilterFalsePositiveRate(t *testing.T) {
+	fpRate := 0.01
+	f, _ := NewScalableBloomFilter(1000, fpRate, 0.8)
+	r := rand.New(rand.NewSource(42))
+
+	added := make(map[uint64]bool)
+	key := make([]byte, 8)
+	for len(added) < 50000 {
+		v := r.Uint64()
+		added[v] = true
+		binary.LittleEndian.PutUint64(key, v)
+		f.Add(key)
+	}
+	assert.Greater(t, f.FilterCount(), 1)
+
+	falsePositives := 0
+	trials := 100000
+	for i := 0; i < trials; i++ {
+		v := r.Uint64()
+		if added[v] {
+			continue
+		}
+		binary.LittleEndian.PutUint64(key, v)
+		if f.Test(key) {
+			falsePositives++
+		}
+	}
+
+	assert.Less(t, float64(falsePositives)/float64(trials), fpRate)
+}
+
+func TestScalableBloomFilterMarshalBinary(t *testing.T) {
+	f, _ := NewScalableBloomFilter(10, 0.01, 0.9)
+
+	key := make([]byte, 8)
+	for i := 0; i < 50; i++ {
+		binary.LittleEndian.PutUint64(key, uint64(i))
+		f.Add(key)
+	}
+
+	data, err := f.MarshalBinary()
+	assert.NoError(t, err)
+
+	decoded := &ScalableBloomFilter{}
+	assert.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, f.Count(), decoded.Count())
+	assert.Equal(t, f.FilterCount(), decoded.FilterCount())
+	for i := 0; i < 50; i++ {
+		binary.LittleEndian.PutUint64(key, uint64(i))
+		assert.True(t, decoded.Test(key))
+	}
+
+	assert.ErrorIs(t, decoded.UnmarshalBinary(data[:16]), ErrCorruptData)
+	assert.ErrorIs(t, decoded.UnmarshalBinary(data[:40]), ErrCorruptData)
+	assert.ErrorIs(t, decoded.UnmarshalBinary(append(data, 0)), ErrCorruptData)
+}
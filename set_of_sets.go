@@ -0,0 +1,101 @@
+package bit
+
+import (
+	"sort"
+)
+
+// SetOfSets stores distinct sets in sorted order of an Ordering.
+// Sets are copied on insertion, so later changes of the inserted sets
+// don't break the order.
+type SetOfSets struct {
+	order Ordering
+	sets  []*Set
+}
+
+// NewSetOfSets creates an empty container of sets sorted in the ordering.
+func NewSetOfSets(order Ordering) *SetOfSets {
+	return &SetOfSets{order: order}
+}
+
+// Len returns the number of sets.
+func (s *SetOfSets) Len() int {
+	return len(s.sets)
+}
+
+// Add inserts a copy of the set, and returns false if an Equal set is
+// already in the container.
+func (s *SetOfSets) Add(set *Set) bool {
+	i, found := s.search(set)
+	if found {
+		return false
+	}
+
+	s.sets = append(s.sets, nil)
+	copy(s.sets[i+1:], s.sets[i:])
+	s.sets[i] = set.Clone()
+	return true
+}
+
+// Remove removes the set which is Equal to the set, and returns false if
+// there is no such set.
+func (s *SetOfSets) Remove(set *Set) bool {
+	i, found := s.search(set)
+	if !found {
+		return false
+	}
+
+	copy(s.sets[i:], s.sets[i+1:])
+	s.sets[len(s.sets)-1] = nil
+	s.sets = s.sets[:len(s.sets)-1]
+	return true
+}
+
+// Contains checks whether a set Equal to the set is in the container.
+func (s *SetOfSets) Contains(set *Set) bool {
+	_, found := s.search(set)
+	return found
+}
+
+// Index returns the position of the set which is Equal to the set in the
+// sorted order, or -1 if there is no such set.
+func (s *SetOfSets) Index(set *Set) int {
+	i, found := s.search(set)
+	if !found {
+		return -1
+	}
+	return i
+}
+
+// At returns the i-th set in the sorted order, it should not be modified.
+// It panics if i is out of range.
+func (s *SetOfSets) At(i int) *Set {
+	return s.sets[i]
+}
+
+// Iterate calls fn for every set in the sorted order, until fn returns false.
+// The sets should not be modified.
+func (s *SetOfSets) Iterate(fn func(set *Set) bool) {
+	for _, set := range s.sets {
+		if !fn(set) {
+			return
+		}
+	}
+}
+
+// Sets returns copies of the sets in the sorted order.
+func (s *SetOfSets) Sets() []*Set {
+	sets := make([]*Set, len(s.sets))
+	for i, set := range s.sets {
+		sets[i] = set.Clone()
+	}
+	return sets
+}
+
+// search returns the position of the set in the sorted order, and whether
+// it is already in the container
+func (s *SetOfSets) search(set *Set) (int, bool) {
+	i := sort.Search(len(s.sets), func(i int) bool {
+		return s.order.Compare(s.sets[i], set) >= 0
+	})
+	return i, i < len(s.sets) && s.sets[i].Equal(set)
+}
@@ -0,0 +1,54 @@
+package bit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetOfSets(t *testing.T) {
+	s := NewSetOfSets(LexicographicOrder)
+
+	for _, indices := range [][]int{{2}, {1, 5}, {1, 3, 4}, {1, 3}, {1, 5}} {
+		set, _ := FromIndices(indices)
+		s.Add(set)
+	}
+	assert.Equal(t, 4, s.Len())
+
+	var sorted []string
+	s.Iterate(func(set *Set) bool {
+		sorted = append(sorted, set.String())
+		return true
+	})
+	assert.Equal(t, []string{"{1, 3}", "{1, 3, 4}", "{1, 5}", "{2}"}, sorted)
+
+	set, _ := NewSet(WithInitialBits(1000))
+	set.Set(1).Set(5)
+	assert.False(t, s.Add(set), "equal sets of different sizes are the same")
+	assert.True(t, s.Contains(set))
+	assert.Equal(t, 2, s.Index(set))
+
+	// the container keeps its own copies
+	set.Set(7)
+	assert.Equal(t, "{1, 5}", s.At(2).String())
+	assert.False(t, s.Contains(set))
+	assert.Equal(t, -1, s.Index(set))
+
+	set.Clear(7)
+	assert.True(t, s.Remove(set))
+	assert.False(t, s.Remove(set))
+	assert.Equal(t, 3, s.Len())
+
+	sets := s.Sets()
+	sets[0].Set(100)
+	assert.Equal(t, "{1, 3}", s.At(0).String())
+
+	numeric := NewSetOfSets(NumericOrder)
+	for _, indices := range [][]int{{2}, {1, 5}, {0}} {
+		set, _ := FromIndices(indices)
+		numeric.Add(set)
+	}
+	assert.Equal(t, "{0}", numeric.At(0).String())
+	assert.Equal(t, "{2}", numeric.At(1).String())
+	assert.Equal(t, "{1, 5}", numeric.At(2).String())
+}
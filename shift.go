@@ -0,0 +1,163 @@
+package bit
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// ShiftLeft moves every bit n positions towards the higher indices, so the
+// bit at index i moves to index i+n, and the lowest n bits become false.
+// The set grows as needed, and bits moved past the bound of the set are dropped.
+// If n is negative no change will happen, or it panics in strict mode.
+func (set *Set) ShiftLeft(n int) *Set {
+	if n < 0 {
+		set.misuse(fmt.Errorf("%w: Shift is negative: %d", ErrInvalidArgument, n))
+		return set
+	}
+	if n == 0 {
+		return set
+	}
+
+	length := set.Length()
+	if length == 0 {
+		return set
+	}
+
+	set.invalidate()
+	set.expandIfNeeded((length - 1 + n) / minBits)
+	shiftWordsLeft(set.arr, n)
+	set.clearOutOfBounds()
+	return set
+}
+
+// ShiftRight moves every bit n positions towards the lower indices, so the
+// bit at index i moves to index i-n, and the lowest n bits are dropped.
+// If n is negative no change will happen, or it panics in strict mode.
+func (set *Set) ShiftRight(n int) *Set {
+	if n < 0 {
+		set.misuse(fmt.Errorf("%w: Shift is negative: %d", ErrInvalidArgument, n))
+		return set
+	}
+	if n == 0 {
+		return set
+	}
+
+	set.invalidate()
+	shiftWordsRight(set.arr, n)
+	set.shrinkIfNeeded()
+	return set
+}
+
+// RotateLeft rotates the lowest width bits n positions towards the higher
+// indices, so the bit at index i moves to index (i+n) mod width.
+// The bits at or above width are not changed.
+// If n or width is negative no change will happen, or it panics in strict mode.
+func (set *Set) RotateLeft(n int, width int) *Set {
+	if n < 0 || width < 0 {
+		set.misuse(fmt.Errorf("%w: Rotation or width is negative: %d, %d", ErrInvalidArgument, n, width))
+		return set
+	}
+	if width == 0 {
+		return set
+	}
+
+	n %= width
+	if n == 0 {
+		return set
+	}
+
+	low := set.GetRange(0, width).arr[:howManyUint64(width)]
+	high := make([]uint64, len(low))
+	copy(high, low)
+
+	shiftWordsLeft(low, n)
+	shiftWordsRight(high, width-n)
+	for i := range low {
+		low[i] |= high[i]
+	}
+
+	return set.setLowBits(width, low)
+}
+
+// RotateRight rotates the lowest width bits n positions towards the lower
+// indices, so the bit at index i moves to index (i-n) mod width.
+// The bits at or above width are not changed.
+// If n or width is negative no change will happen, or it panics in strict mode.
+func (set *Set) RotateRight(n int, width int) *Set {
+	if n < 0 || width < 0 {
+		set.misuse(fmt.Errorf("%w: Rotation or width is negative: %d, %d", ErrInvalidArgument, n, width))
+		return set
+	}
+	if width == 0 {
+		return set
+	}
+
+	return set.RotateLeft(width-n%width, width)
+}
+
+// Reverse reverses the order of the lowest width bits, so the bit at index i
+// moves to index width-1-i. The bits at or above width are not changed.
+// If width is negative no change will happen, or it panics in strict mode.
+func (set *Set) Reverse(width int) *Set {
+	if width < 0 {
+		set.misuse(fmt.Errorf("%w: Width is negative: %d", ErrInvalidArgument, width))
+		return set
+	}
+	if width == 0 {
+		return set
+	}
+
+	words := set.GetRange(0, width).arr[:howManyUint64(width)]
+	reversed := make([]uint64, len(words))
+	for i, word := range words {
+		reversed[len(words)-1-i] = bits.Reverse64(word)
+	}
+	// the reversed bits are aligned to the end of the last word
+	shiftWordsRight(reversed, len(words)*minBits-width)
+
+	return set.setLowBits(width, reversed)
+}
+
+// setLowBits replaces the lowest width bits of the set with the words
+func (set *Set) setLowBits(width int, words []uint64) *Set {
+	for i, word := range words {
+		set.SetBits(i*minBits, min(minBits, width-i*minBits), word)
+	}
+	return set
+}
+
+// shiftWordsLeft shifts the bits of the words n positions towards the higher
+// indices, the bits moving past the last word are dropped
+func shiftWordsLeft(arr []uint64, n int) {
+	wordShift, bitShift := n/minBits, uint(n%minBits)
+
+	for i := len(arr) - 1; i >= 0; i-- {
+		src := i - wordShift
+		var word uint64
+		if src >= 0 {
+			word = arr[src] << bitShift
+			if bitShift > 0 && src > 0 {
+				word |= arr[src-1] >> (minBits - bitShift)
+			}
+		}
+		arr[i] = word
+	}
+}
+
+// shiftWordsRight shifts the bits of the words n positions towards the lower
+// indices, the bits moving past the first word are dropped
+func shiftWordsRight(arr []uint64, n int) {
+	wordShift, bitShift := n/minBits, uint(n%minBits)
+
+	for i := range arr {
+		src := i + wordShift
+		var word uint64
+		if src < len(arr) {
+			word = arr[src] >> bitShift
+			if bitShift > 0 && src+1 < len(arr) {
+				word |= arr[src+1] << (minBits - bitShift)
+			}
+		}
+		arr[i] = word
+	}
+}
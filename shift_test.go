@@ -0,0 +1,117 @@
+package bit
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShiftLeft(t *testing.T) {
+	testCases := []struct {
+		set      *Set
+		n        int
+		expected *Set
+	}{
+		{set: ValueOf([]uint64{5}), n: 0, expected: ValueOf([]uint64{5})},
+		{set: ValueOf([]uint64{5}), n: -3, expected: ValueOf([]uint64{5})},
+		{set: ValueOf([]uint64{5}), n: 2, expected: ValueOf([]uint64{20})},
+		{set: ValueOf([]uint64{1 << 63}), n: 1, expected: ValueOf([]uint64{0, 1})},
+		{set: ValueOf([]uint64{5}), n: 64, expected: ValueOf([]uint64{0, 5})},
+		{set: ValueOf([]uint64{3 << 62, 1}), n: 66, expected: ValueOf([]uint64{0, 0, 7})},
+		{set: ValueOf([]uint64{0}), n: 100, expected: ValueOf([]uint64{0})},
+	}
+
+	for _, test := range testCases {
+		assert.True(t, test.expected.Equal(test.set.ShiftLeft(test.n)))
+	}
+}
+
+func TestShiftRight(t *testing.T) {
+	testCases := []struct {
+		set      *Set
+		n        int
+		expected *Set
+	}{
+		{set: ValueOf([]uint64{5}), n: -3, expected: ValueOf([]uint64{5})},
+		{set: ValueOf([]uint64{20}), n: 2, expected: ValueOf([]uint64{5})},
+		{set: ValueOf([]uint64{0, 1}), n: 1, expected: ValueOf([]uint64{1 << 63})},
+		{set: ValueOf([]uint64{7, 5}), n: 64, expected: ValueOf([]uint64{5})},
+		{set: ValueOf([]uint64{0, 0, 7}), n: 66, expected: ValueOf([]uint64{3 << 62, 1})},
+		{set: ValueOf([]uint64{7}), n: 100, expected: ValueOf([]uint64{0})},
+	}
+
+	for _, test := range testCases {
+		assert.True(t, test.expected.Equal(test.set.ShiftRight(test.n)))
+	}
+}
+
+func TestRotate(t *testing.T) {
+	testCases := []struct {
+		set      *Set
+		n        int
+		width    int
+		expected *Set
+	}{
+		{set: ValueOf([]uint64{0x9}), n: 1, width: 4, expected: ValueOf([]uint64{0x3})},
+		{set: ValueOf([]uint64{0x9}), n: 5, width: 4, expected: ValueOf([]uint64{0x3})},
+		{set: ValueOf([]uint64{0x19}), n: 1, width: 4, expected: ValueOf([]uint64{0x13})},
+		{set: ValueOf([]uint64{1 << 63, 1}), n: 1, width: 65, expected: ValueOf([]uint64{1, 1})},
+		{set: ValueOf([]uint64{0x9}), n: 0, width: 4, expected: ValueOf([]uint64{0x9})},
+	}
+
+	for _, test := range testCases {
+		assert.True(t, test.expected.Equal(test.set.Clone().RotateLeft(test.n, test.width)))
+		assert.True(t, test.set.Equal(test.expected.Clone().RotateRight(test.n, test.width)))
+	}
+}
+
+func TestReverse(t *testing.T) {
+	testCases := []struct {
+		set      *Set
+		width    int
+		expected *Set
+	}{
+		{set: ValueOf([]uint64{0x1}), width: 4, expected: ValueOf([]uint64{0x8})},
+		{set: ValueOf([]uint64{0x13}), width: 4, expected: ValueOf([]uint64{0x1C})},
+		{set: ValueOf([]uint64{0x1}), width: 64, expected: ValueOf([]uint64{1 << 63})},
+		{set: ValueOf([]uint64{0x3, 0x4}), width: 66, expected: ValueOf([]uint64{0, 0x7})},
+		{set: ValueOf([]uint64{0x1}), width: 0, expected: ValueOf([]uint64{0x1})},
+	}
+
+	for _, test := range testCases {
+		assert.True(t, test.expected.Equal(test.set.Reverse(test.width)))
+	}
+}
+
+func TestShiftRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 50; i++ {
+		arr := make([]uint64, 1+r.Intn(4))
+		for j := range arr {
+			arr[j] = r.Uint64()
+		}
+		set := ValueOf(arr)
+		size := set.Size()
+		n := r.Intn(200)
+		width := 1 + r.Intn(size)
+
+		left := set.Clone().ShiftLeft(n)
+		right := set.Clone().ShiftRight(n)
+		rotated := set.Clone().RotateLeft(n, width)
+		reversed := set.Clone().Reverse(width)
+
+		for j := 0; j < size+n; j++ {
+			assert.Equal(t, set.Get(j), left.Get(j+n))
+			assert.Equal(t, set.Get(j+n), right.Get(j))
+			if j < width {
+				assert.Equal(t, set.Get(j), rotated.Get((j+n)%width))
+				assert.Equal(t, set.Get(j), reversed.Get(width-1-j))
+			} else {
+				assert.Equal(t, set.Get(j), rotated.Get(j))
+				assert.Equal(t, set.Get(j), reversed.Get(j))
+			}
+		}
+	}
+}
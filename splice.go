@@ -0,0 +1,84 @@
+package bit
+
+import (
+	"fmt"
+)
+
+// InsertRange inserts n bits with the specified value at the index, moving
+// every bit at or above the index n positions up, like inserting into a slice.
+// The set grows as needed, and bits moved past the bound of the set are dropped.
+// If index or n is negative no change will happen, or it panics in strict mode.
+func (set *Set) InsertRange(index int, n int, value bool) *Set {
+	if index < 0 {
+		set.misuse(fmt.Errorf("%w: %d", ErrNegativeIndex, index))
+		return set
+	}
+	if n < 0 {
+		set.misuse(fmt.Errorf("%w: Number of bits is negative: %d", ErrInvalidArgument, n))
+		return set
+	}
+	if n == 0 {
+		return set
+	}
+
+	length := set.Length()
+	if index >= length && !value {
+		// all is clear outside boundary
+		return set
+	}
+
+	if index < length {
+		tail := set.GetRange(index, length)
+		set.copyWords(index+n, length-index, tail.arr)
+	}
+
+	return set.fillRange(index, index+n, value)
+}
+
+// DeleteRange removes the bits from the specified fromIndex (inclusive) to
+// the specified toIndex (exclusive), moving every bit at or above toIndex
+// down to fromIndex, like deleting from a slice.
+// A negative fromIndex is treated as zero and a reversed range changes
+// nothing, both panic in strict mode instead.
+func (set *Set) DeleteRange(fromIndex int, toIndex int) *Set {
+	fromIndex, toIndex, ok := set.checkRange(fromIndex, toIndex)
+	if !ok {
+		return set
+	}
+
+	length := set.Length()
+	if toIndex == fromIndex || fromIndex >= length {
+		return set
+	}
+	if toIndex > length {
+		toIndex = length
+	}
+
+	tail := set.GetRange(toIndex, length)
+	set.fillRange(fromIndex, length, false)
+	set.copyWords(fromIndex, length-toIndex, tail.arr)
+	set.shrinkIfNeeded()
+	return set
+}
+
+// copyWords writes nbits bits of the words starting at the index
+func (set *Set) copyWords(index int, nbits int, words []uint64) *Set {
+	for i := 0; i*minBits < nbits; i++ {
+		set.writeField(index+i*minBits, min(minBits, nbits-i*minBits), words[i])
+	}
+	return set
+}
+
+// fillRange sets the bits from fromIndex (inclusive) to toIndex (exclusive)
+// to the specified value, a word at a time
+func (set *Set) fillRange(fromIndex int, toIndex int, value bool) *Set {
+	var word uint64
+	if value {
+		word = ^uint64(0)
+	}
+
+	for i := fromIndex; i < toIndex; i += minBits {
+		set.writeField(i, min(minBits, toIndex-i), word)
+	}
+	return set
+}
@@ -0,0 +1,87 @@
+package bit
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertRange(t *testing.T) {
+	testCases := []struct {
+		set      *Set
+		index    int
+		n        int
+		value    bool
+		expected *Set
+	}{
+		{set: ValueOf([]uint64{0xF}), index: 2, n: 3, value: false, expected: ValueOf([]uint64{0x63})},
+		{set: ValueOf([]uint64{0xF}), index: 2, n: 3, value: true, expected: ValueOf([]uint64{0x7F})},
+		{set: ValueOf([]uint64{0xF}), index: 0, n: 64, value: false, expected: ValueOf([]uint64{0, 0xF})},
+		{set: ValueOf([]uint64{0xF}), index: 10, n: 2, value: true, expected: ValueOf([]uint64{0xC0F})},
+		{set: ValueOf([]uint64{0xF}), index: 10, n: 2, value: false, expected: ValueOf([]uint64{0xF})},
+		{set: ValueOf([]uint64{1 << 63}), index: 1, n: 1, value: false, expected: ValueOf([]uint64{0, 1})},
+		{set: ValueOf([]uint64{0xF}), index: -1, n: 2, value: true, expected: ValueOf([]uint64{0xF})},
+		{set: ValueOf([]uint64{0xF}), index: 1, n: 0, value: true, expected: ValueOf([]uint64{0xF})},
+	}
+
+	for _, test := range testCases {
+		assert.True(t, test.expected.Equal(test.set.InsertRange(test.index, test.n, test.value)))
+	}
+}
+
+func TestDeleteRange(t *testing.T) {
+	testCases := []struct {
+		set       *Set
+		fromIndex int
+		toIndex   int
+		expected  *Set
+	}{
+		{set: ValueOf([]uint64{0x63}), fromIndex: 2, toIndex: 5, expected: ValueOf([]uint64{0xF})},
+		{set: ValueOf([]uint64{0, 0xF}), fromIndex: 0, toIndex: 64, expected: ValueOf([]uint64{0xF})},
+		{set: ValueOf([]uint64{0, 1}), fromIndex: -5, toIndex: 1, expected: ValueOf([]uint64{1 << 63})},
+		{set: ValueOf([]uint64{0xFF}), fromIndex: 4, toIndex: 100, expected: ValueOf([]uint64{0xF})},
+		{set: ValueOf([]uint64{0xFF}), fromIndex: 10, toIndex: 20, expected: ValueOf([]uint64{0xFF})},
+		{set: ValueOf([]uint64{0xFF}), fromIndex: 5, toIndex: 2, expected: ValueOf([]uint64{0xFF})},
+	}
+
+	for _, test := range testCases {
+		assert.True(t, test.expected.Equal(test.set.DeleteRange(test.fromIndex, test.toIndex)))
+	}
+}
+
+func TestSpliceRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 50; i++ {
+		bools := make([]bool, r.Intn(300))
+		set, _ := NewSet()
+		for j := range bools {
+			bools[j] = r.Intn(2) == 1
+			set.SetValue(j, bools[j])
+		}
+
+		index := r.Intn(len(bools) + 1)
+		n := r.Intn(150)
+		value := r.Intn(2) == 1
+
+		inserted := make([]bool, 0, len(bools)+n)
+		inserted = append(inserted, bools[:index]...)
+		for j := 0; j < n; j++ {
+			inserted = append(inserted, value)
+		}
+		inserted = append(inserted, bools[index:]...)
+
+		set.InsertRange(index, n, value)
+		for j, b := range inserted {
+			assert.Equal(t, b, set.Get(j))
+		}
+		assert.False(t, set.Get(len(inserted)))
+
+		set.DeleteRange(index, index+n)
+		for j, b := range bools {
+			assert.Equal(t, b, set.Get(j))
+		}
+		assert.True(t, set.Length() <= len(bools))
+	}
+}
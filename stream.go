@@ -0,0 +1,349 @@
+package bit
+
+import (
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// BitOrder determines in which order the bits of fields are written and read.
+type BitOrder int
+
+const (
+	// MSBFirst writes the most significant bit of every field first, and fills
+	// every byte of the stream from its most significant bit,
+	// as most network protocols do.
+	MSBFirst BitOrder = iota
+	// LSBFirst writes the least significant bit of every field first, and fills
+	// every byte of the stream from its least significant bit,
+	// as DEFLATE does.
+	LSBFirst
+)
+
+const (
+	// size of the buffer of writers and readers on top of io.Writer or io.Reader
+	streamBufferSize = 4096
+)
+
+var errInvalidWidth = fmt.Errorf("%w: Number of bits should be between 0 and 64", ErrInvalidArgument)
+
+// Writer writes fields of bits to an io.Writer or to a Set.
+// When writing to a Set, the k-th bit of the stream is stored at index k.
+type Writer struct {
+	order BitOrder
+
+	// destination is either w or set
+	w   io.Writer
+	set *Set
+
+	buf   []byte // bytes not yet written to w, the last one may be partial
+	nbits int    // number of bits written so far
+	err   error
+}
+
+// NewWriter creates a writer on top of the io.Writer. Bits are buffered,
+// so Flush must be called when writing is finished.
+func NewWriter(w io.Writer, order BitOrder) *Writer {
+	return &Writer{
+		order: order,
+		w:     w,
+		buf:   make([]byte, 0, streamBufferSize),
+	}
+}
+
+// NewSetWriter creates a writer which writes to the set from index 0,
+// growing the set as needed. Writes past the bound of the set return an error.
+func NewSetWriter(set *Set, order BitOrder) *Writer {
+	return &Writer{
+		order: order,
+		set:   set,
+	}
+}
+
+// Count returns the number of bits written so far.
+func (w *Writer) Count() int {
+	return w.nbits
+}
+
+// WriteBits writes the lowest n bits of the value, n should be between 0 and 64.
+func (w *Writer) WriteBits(v uint64, n int) error {
+	if w.err != nil {
+		return w.err
+	}
+	if n < 0 || n > minBits {
+		return errInvalidWidth
+	}
+	if n == 0 {
+		return nil
+	}
+	if n < minBits {
+		v &= 1<<uint(n) - 1
+	}
+
+	if w.set != nil {
+		if !w.set.inBounds(w.nbits + n - 1) {
+			return fmt.Errorf("%w: %d", ErrOutOfBounds, w.nbits+n-1)
+		}
+		w.writeToSet(v, n)
+		return nil
+	}
+
+	w.writeToBuffer(v, n)
+	if len(w.buf) >= streamBufferSize {
+		return w.flushBuffer(false)
+	}
+	return nil
+}
+
+// WriteBool writes a single bit.
+func (w *Writer) WriteBool(value bool) error {
+	if value {
+		return w.WriteBits(1, 1)
+	}
+	return w.WriteBits(0, 1)
+}
+
+// Flush writes the buffered bytes to the underlying io.Writer. A partial last
+// byte is padded with zero bits, so the next write starts at a byte boundary.
+// Flush does nothing for a writer on a set.
+func (w *Writer) Flush() error {
+	if w.err != nil {
+		return w.err
+	}
+	if w.set != nil {
+		return nil
+	}
+
+	if rem := w.nbits % 8; rem != 0 {
+		w.nbits += 8 - rem
+	}
+	return w.flushBuffer(true)
+}
+
+// flushBuffer writes the buffered bytes, except for a partial last byte
+// if all is false
+func (w *Writer) flushBuffer(all bool) error {
+	n := len(w.buf)
+	if !all && w.nbits%8 != 0 {
+		n--
+	}
+
+	if _, err := w.w.Write(w.buf[:n]); err != nil {
+		w.err = err
+		return err
+	}
+
+	rest := copy(w.buf, w.buf[n:])
+	w.buf = w.buf[:rest]
+	return nil
+}
+
+func (w *Writer) writeToSet(v uint64, n int) {
+	if w.order == MSBFirst {
+		v = reverseBits(v, n)
+	}
+
+	w.set.invalidate()
+	w.set.expandIfNeeded((w.nbits + n - 1) / minBits)
+	writeBits(w.set.arr, w.nbits, n, v)
+	w.nbits += n
+}
+
+func (w *Writer) writeToBuffer(v uint64, n int) {
+	for n > 0 {
+		offset := w.nbits % 8
+		if offset == 0 {
+			w.buf = append(w.buf, 0)
+		}
+
+		take := min(8-offset, n)
+		last := len(w.buf) - 1
+		if w.order == MSBFirst {
+			chunk := byte(v>>uint(n-take)) & (1<<uint(take) - 1)
+			w.buf[last] |= chunk << uint(8-offset-take)
+		} else {
+			chunk := byte(v) & (1<<uint(take) - 1)
+			w.buf[last] |= chunk << uint(offset)
+			v >>= uint(take)
+		}
+
+		n -= take
+		w.nbits += take
+	}
+}
+
+// Reader reads fields of bits from an io.Reader or from a Set.
+// When reading from a Set, the k-th bit of the stream is taken from index k,
+// and the stream ends at Size() of the set.
+type Reader struct {
+	order BitOrder
+
+	// source is either r or set
+	r   io.Reader
+	set *Set
+
+	buf   []byte // bytes read from r but not consumed yet
+	pos   int    // position of the next bit within buf
+	nbits int    // number of bits read so far
+	err   error  // error returned by r
+}
+
+// NewReader creates a reader on top of the io.Reader.
+func NewReader(r io.Reader, order BitOrder) *Reader {
+	return &Reader{
+		order: order,
+		r:     r,
+		buf:   make([]byte, 0, streamBufferSize),
+	}
+}
+
+// NewSetReader creates a reader which reads the set from index 0.
+func NewSetReader(set *Set, order BitOrder) *Reader {
+	return &Reader{
+		order: order,
+		set:   set,
+	}
+}
+
+// Count returns the number of bits read or skipped so far.
+func (r *Reader) Count() int {
+	return r.nbits
+}
+
+// ReadBits reads n bits, n should be between 0 and 64.
+// If the stream ends before n bits, io.ErrUnexpectedEOF is returned, or
+// io.EOF if no bits are left at all.
+func (r *Reader) ReadBits(n int) (uint64, error) {
+	v, err := r.PeekBits(n)
+	if err != nil {
+		return 0, err
+	}
+
+	r.advance(n)
+	return v, nil
+}
+
+// ReadBool reads a single bit.
+func (r *Reader) ReadBool() (bool, error) {
+	v, err := r.ReadBits(1)
+	return v == 1, err
+}
+
+// PeekBits returns the next n bits without advancing the reader.
+func (r *Reader) PeekBits(n int) (uint64, error) {
+	if n < 0 || n > minBits {
+		return 0, errInvalidWidth
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	if err := r.ensure(n); err != nil {
+		return 0, err
+	}
+
+	if r.set != nil {
+		v := readBits(r.set.arr, r.nbits, n)
+		if r.order == MSBFirst {
+			v = reverseBits(v, n)
+		}
+		return v, nil
+	}
+
+	return r.readFromBuffer(n), nil
+}
+
+// Skip skips n bits.
+func (r *Reader) Skip(n int) error {
+	if n < 0 {
+		return fmt.Errorf("%w: Number of bits is negative", ErrInvalidArgument)
+	}
+
+	for n > 0 {
+		step := min(n, minBits)
+		if err := r.ensure(step); err != nil {
+			return err
+		}
+		r.advance(step)
+		n -= step
+	}
+	return nil
+}
+
+// ensure checks that at least n bits are available
+func (r *Reader) ensure(n int) error {
+	available := 0
+	if r.set != nil {
+		available = r.set.Size() - r.nbits
+	} else {
+		available = r.fill(n)
+	}
+
+	if available >= n {
+		return nil
+	}
+	if r.err != nil && r.err != io.EOF {
+		return r.err
+	}
+	if available == 0 {
+		return io.EOF
+	}
+	return io.ErrUnexpectedEOF
+}
+
+// fill reads from r until n bits are buffered or r has no more data,
+// and returns the number of buffered bits
+func (r *Reader) fill(n int) int {
+	// drop consumed bytes
+	if consumed := r.pos / 8; consumed > 0 {
+		rest := copy(r.buf, r.buf[consumed:])
+		r.buf = r.buf[:rest]
+		r.pos -= consumed * 8
+	}
+
+	for len(r.buf)*8-r.pos < n && r.err == nil {
+		if len(r.buf) == cap(r.buf) {
+			r.buf = append(r.buf, 0)[:len(r.buf)]
+		}
+
+		var read int
+		read, r.err = r.r.Read(r.buf[len(r.buf):cap(r.buf)])
+		r.buf = r.buf[:len(r.buf)+read]
+	}
+
+	return len(r.buf)*8 - r.pos
+}
+
+func (r *Reader) advance(n int) {
+	r.nbits += n
+	if r.set == nil {
+		r.pos += n
+	}
+}
+
+func (r *Reader) readFromBuffer(n int) uint64 {
+	var v uint64
+	pos := r.pos
+	for got := 0; got < n; {
+		offset := pos % 8
+		take := min(8-offset, n-got)
+		b := uint64(r.buf[pos/8])
+
+		if r.order == MSBFirst {
+			chunk := (b >> uint(8-offset-take)) & (1<<uint(take) - 1)
+			v = v<<uint(take) | chunk
+		} else {
+			chunk := (b >> uint(offset)) & (1<<uint(take) - 1)
+			v |= chunk << uint(got)
+		}
+
+		got += take
+		pos += take
+	}
+
+	return v
+}
+
+// reverseBits reverses the order of the lowest n bits of the value
+func reverseBits(v uint64, n int) uint64 {
+	return bits.Reverse64(v) >> uint(minBits-n)
+}
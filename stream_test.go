@@ -0,0 +1,185 @@
+package bit
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriter(t *testing.T) {
+	testCases := []struct {
+		order    BitOrder
+		expected []byte
+	}{
+		{order: MSBFirst, expected: []byte{0xA3, 0xFE, 0x00, 0x40}},
+		{order: LSBFirst, expected: []byte{0xFD, 0x47, 0x00, 0x04}},
+	}
+
+	for _, test := range testCases {
+		buf := &bytes.Buffer{}
+		w := NewWriter(buf, test.order)
+
+		assert.NoError(t, w.WriteBits(5, 3))     // 101
+		assert.NoError(t, w.WriteBits(0xFF, 11)) // 00011111111
+		assert.NoError(t, w.WriteBool(true))
+		assert.NoError(t, w.WriteBits(0, 10))
+		assert.NoError(t, w.WriteBits(2, 2)) // 10
+		assert.Equal(t, 27, w.Count())
+		assert.NoError(t, w.Flush())
+		assert.Equal(t, 32, w.Count())
+
+		assert.Equal(t, test.expected, buf.Bytes())
+	}
+}
+
+func TestWriterInvalidWidth(t *testing.T) {
+	w := NewWriter(&bytes.Buffer{}, MSBFirst)
+	assert.Error(t, w.WriteBits(0, 65))
+	assert.Error(t, w.WriteBits(0, -1))
+	assert.NoError(t, w.WriteBits(7, 0))
+	assert.Equal(t, 0, w.Count())
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestWriterError(t *testing.T) {
+	w := NewWriter(failingWriter{}, MSBFirst)
+	assert.NoError(t, w.WriteBits(1, 8))
+	assert.Error(t, w.Flush())
+	assert.Error(t, w.WriteBits(1, 8))
+}
+
+func TestSetWriter(t *testing.T) {
+	set, _ := NewSet()
+	w := NewSetWriter(set, LSBFirst)
+	assert.NoError(t, w.WriteBits(5, 3))
+	assert.NoError(t, w.WriteBits(1<<63, 64))
+	assert.Equal(t, "{0, 2, 66}", set.String())
+
+	set, _ = NewSet()
+	w = NewSetWriter(set, MSBFirst)
+	assert.NoError(t, w.WriteBits(6, 3))
+	assert.NoError(t, w.WriteBits(1, 64))
+	assert.Equal(t, "{0, 1, 66}", set.String())
+	assert.NoError(t, w.Flush())
+}
+
+func TestReader(t *testing.T) {
+	testCases := []struct {
+		order BitOrder
+		data  []byte
+	}{
+		{order: MSBFirst, data: []byte{0xA3, 0xFE, 0x00, 0x40}},
+		{order: LSBFirst, data: []byte{0xFD, 0x47, 0x00, 0x04}},
+	}
+
+	for _, test := range testCases {
+		r := NewReader(bytes.NewReader(test.data), test.order)
+
+		v, err := r.PeekBits(3)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(5), v)
+
+		v, err = r.ReadBits(3)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(5), v)
+
+		v, err = r.ReadBits(11)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(0xFF), v)
+
+		b, err := r.ReadBool()
+		assert.NoError(t, err)
+		assert.True(t, b)
+
+		assert.NoError(t, r.Skip(10))
+		v, err = r.ReadBits(2)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(2), v)
+		assert.Equal(t, 27, r.Count())
+
+		_, err = r.ReadBits(6)
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+		assert.NoError(t, r.Skip(5))
+		_, err = r.ReadBits(1)
+		assert.Equal(t, io.EOF, err)
+	}
+}
+
+func TestSetReader(t *testing.T) {
+	set := ValueOf([]uint64{5 | 1<<63})
+
+	r := NewSetReader(set, LSBFirst)
+	v, err := r.ReadBits(3)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), v)
+
+	r = NewSetReader(set, MSBFirst)
+	v, err = r.ReadBits(3)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), v)
+
+	assert.NoError(t, r.Skip(60))
+	b, err := r.ReadBool()
+	assert.NoError(t, err)
+	assert.True(t, b)
+
+	_, err = r.ReadBits(1)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestStreamRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+
+	for _, order := range []BitOrder{MSBFirst, LSBFirst} {
+		widths := make([]int, 2000)
+		values := make([]uint64, len(widths))
+		for i := range widths {
+			widths[i] = r.Intn(65)
+			values[i] = r.Uint64()
+			if widths[i] < 64 {
+				values[i] &= 1<<uint(widths[i]) - 1
+			}
+		}
+
+		buf := &bytes.Buffer{}
+		set, _ := NewSet()
+		w := NewWriter(buf, order)
+		sw := NewSetWriter(set, order)
+		for i := range widths {
+			assert.NoError(t, w.WriteBits(values[i], widths[i]))
+			assert.NoError(t, sw.WriteBits(values[i], widths[i]))
+		}
+		assert.NoError(t, w.Flush())
+
+		reader := NewReader(buf, order)
+		setReader := NewSetReader(set, order)
+		for i := range widths {
+			v, err := reader.ReadBits(widths[i])
+			assert.NoError(t, err)
+			assert.Equal(t, values[i], v)
+
+			v, err = setReader.ReadBits(widths[i])
+			assert.NoError(t, err)
+			assert.Equal(t, values[i], v)
+		}
+	}
+}
+
+func TestSetWriterBound(t *testing.T) {
+	set, _ := NewSet(WithFixedSize(10))
+	w := NewSetWriter(set, LSBFirst)
+
+	assert.NoError(t, w.WriteBits(0xFF, 8))
+	assert.Error(t, w.WriteBits(0xFF, 3))
+	assert.NoError(t, w.WriteBits(0x3, 2))
+	assert.Equal(t, 10, set.Cardinality())
+}
@@ -0,0 +1,348 @@
+package bit
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// maximum value of int
+const maxInt = int(^uint(0) >> 1)
+
+// maxIndex is the highest index of a UintSet. It is the highest index of
+// a Set, but every uint32 index is supported on 32-bit targets too, as
+// its word index always fits in int.
+const maxIndex = uint64(maxInt) | math.MaxUint32
+
+// Unsigned is the set of unsigned integer types which can index a UintSet.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// UintSet is a Set indexed by an unsigned integer type, such as uint32 user
+// IDs or uint64 offsets, so no casting is needed at the call sites.
+//
+// The word arithmetic is done in uint64, so every uint32 index works on
+// 32-bit targets too, though not through the underlying Set, see BitSet. Indices which a Set can't hold, past the maximum value
+// of int on 64-bit targets, are past the bound of the set: Get returns false,
+// writes apply the bound policy and the Try variants return ErrOutOfBounds.
+type UintSet[I Unsigned] struct {
+	set *Set
+}
+
+// NewUintSet creates a set indexed by I with the options of NewSet.
+func NewUintSet[I Unsigned](options ...Option) (*UintSet[I], error) {
+	set, err := NewSet(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UintSet[I]{set: set}, nil
+}
+
+// UintSetOf creates a set indexed by I on top of the set. Both share the
+// same bits, so changes to one are visible in the other.
+func UintSetOf[I Unsigned](set *Set) *UintSet[I] {
+	return &UintSet[I]{set: set}
+}
+
+// BitSet returns the underlying set. On 32-bit targets the methods of Set
+// can't address the bits past the maximum value of int, such as the uint32
+// indices from 2^31, and Size and Length of the Set overflow when such bits are
+// set, so they should only be accessed by the methods of UintSet.
+func (s *UintSet[I]) BitSet() *Set {
+	return s.set
+}
+
+// Set sets the bit at the specified index to true.
+func (s *UintSet[I]) Set(index I) *UintSet[I] {
+	s.update(uint64(index), uint64(index), setWord, true)
+	return s
+}
+
+// Clear sets the bit at the specified index to false.
+func (s *UintSet[I]) Clear(index I) *UintSet[I] {
+	s.update(uint64(index), uint64(index), clearWord, false)
+	return s
+}
+
+// Flip sets the bit at the specified index to the complement of its current value.
+func (s *UintSet[I]) Flip(index I) *UintSet[I] {
+	s.update(uint64(index), uint64(index), flipWord, true)
+	return s
+}
+
+// SetValue sets the bit at the specified index to the specified value.
+func (s *UintSet[I]) SetValue(index I, value bool) *UintSet[I] {
+	if value {
+		return s.Set(index)
+	}
+
+	return s.Clear(index)
+}
+
+// Get returns the value of the bit with the specified index.
+func (s *UintSet[I]) Get(index I) bool {
+	arrIndex, ok := wordIndex(uint64(index))
+	if !ok || arrIndex >= len(s.set.arr) {
+		return false
+	}
+
+	return s.set.arr[arrIndex]&(1<<(uint64(index)%minBits)) != 0
+}
+
+// TrySet sets the bit at the specified index to true, or returns an error
+// if index is past the bound of the set.
+func (s *UintSet[I]) TrySet(index I) error {
+	if err := s.checkIndex(uint64(index)); err != nil {
+		return err
+	}
+
+	s.Set(index)
+	return nil
+}
+
+// TryClear sets the bit at the specified index to false, or returns an error
+// if index is past the bound of the set.
+func (s *UintSet[I]) TryClear(index I) error {
+	if err := s.checkIndex(uint64(index)); err != nil {
+		return err
+	}
+
+	s.Clear(index)
+	return nil
+}
+
+// SetRange sets the bits from the specified fromIndex (inclusive)
+// to the specified toIndex (exclusive) to true.
+// A reversed range changes nothing, or panics in strict mode.
+func (s *UintSet[I]) SetRange(fromIndex I, toIndex I) *UintSet[I] {
+	s.updateRange(uint64(fromIndex), uint64(toIndex), setWord, true)
+	return s
+}
+
+// ClearRange sets the bits from the specified fromIndex (inclusive)
+// to the specified toIndex (exclusive) to false.
+// A reversed range changes nothing, or panics in strict mode.
+func (s *UintSet[I]) ClearRange(fromIndex I, toIndex I) *UintSet[I] {
+	s.updateRange(uint64(fromIndex), uint64(toIndex), clearWord, false)
+	return s
+}
+
+// FlipRange sets each bit from the specified fromIndex (inclusive)
+// to the specified toIndex (exclusive) to the complement of its current value.
+// A reversed range changes nothing, or panics in strict mode.
+func (s *UintSet[I]) FlipRange(fromIndex I, toIndex I) *UintSet[I] {
+	s.updateRange(uint64(fromIndex), uint64(toIndex), flipWord, true)
+	return s
+}
+
+// NextSetBit returns the index of the first set bit that occurs on or after
+// the specified starting index. If there is no such bit, ok is false.
+func (s *UintSet[I]) NextSetBit(fromIndex I) (index I, ok bool) {
+	next, ok := s.next(uint64(fromIndex), true)
+	if !ok {
+		return 0, false
+	}
+	return fromUint64[I](next)
+}
+
+// NextClearBit returns the index of the first clear bit that occurs on or
+// after the specified starting index. If there is no such bit within the
+// range of I, ok is false.
+func (s *UintSet[I]) NextClearBit(fromIndex I) (index I, ok bool) {
+	next, _ := s.next(uint64(fromIndex), false)
+	return fromUint64[I](next)
+}
+
+// PreviousSetBit returns the index of the nearest set bit that occurs on or
+// before the specified starting index. If there is no such bit, ok is false.
+func (s *UintSet[I]) PreviousSetBit(fromIndex I) (index I, ok bool) {
+	from := uint64(fromIndex)
+	arrIndex, fits := wordIndex(from)
+	mask := ^uint64(0) >> (minBits - 1 - from%minBits)
+	if !fits || arrIndex >= len(s.set.arr) {
+		// all is clear outside boundary
+		arrIndex = len(s.set.arr) - 1
+		mask = ^uint64(0)
+	}
+
+	for ; arrIndex >= 0; arrIndex-- {
+		if word := s.set.arr[arrIndex] & mask; word != 0 {
+			return fromUint64[I](uint64(arrIndex)*minBits + uint64(minBits-1-bits.LeadingZeros64(word)))
+		}
+		mask = ^uint64(0)
+	}
+
+	return 0, false
+}
+
+// Iterate calls fn with the index of every set bit in order, until fn
+// returns false. Set bits whose index doesn't fit in I are not visited.
+func (s *UintSet[I]) Iterate(fn func(index I) bool) {
+	for arrIndex, word := range s.set.arr {
+		for word != 0 {
+			index, ok := fromUint64[I](uint64(arrIndex)*minBits + uint64(bits.TrailingZeros64(word)))
+			word &= word - 1
+			if !ok {
+				return
+			}
+			if !fn(index) {
+				return
+			}
+		}
+	}
+}
+
+// Cardinality returns the number of bits set to true.
+func (s *UintSet[I]) Cardinality() int {
+	return s.set.Cardinality()
+}
+
+// Length returns the index of the highest set bit plus one, as uint64
+// because it may not fit in I or in int.
+func (s *UintSet[I]) Length() uint64 {
+	for arrIndex := len(s.set.arr) - 1; arrIndex >= 0; arrIndex-- {
+		if word := s.set.arr[arrIndex]; word != 0 {
+			return uint64(arrIndex)*minBits + uint64(bits.Len64(word))
+		}
+	}
+
+	return 0
+}
+
+// IsEmpty returns true if this set contains no bits that are set to true.
+func (s *UintSet[I]) IsEmpty() bool {
+	return s.set.IsEmpty()
+}
+
+// String returns a string representation of the set like Set.String,
+// such as "{1, 3}". Every set bit is written, even if it doesn't fit in I.
+func (s *UintSet[I]) String() string {
+	b := strings.Builder{}
+	b.WriteString("{")
+	for arrIndex, word := range s.set.arr {
+		for word != 0 {
+			if b.Len() > 1 {
+				b.WriteString(", ")
+			}
+			b.WriteString(strconv.FormatUint(uint64(arrIndex)*minBits+uint64(bits.TrailingZeros64(word)), 10))
+			word &= word - 1
+		}
+	}
+	b.WriteString("}")
+
+	return b.String()
+}
+
+// functions which update the bits of a word selected by a mask
+func setWord(word uint64, mask uint64) uint64   { return word | mask }
+func clearWord(word uint64, mask uint64) uint64 { return word &^ mask }
+func flipWord(word uint64, mask uint64) uint64  { return word ^ mask }
+
+// updateRange applies op to the bits from fromIndex (inclusive) to toIndex
+// (exclusive)
+func (s *UintSet[I]) updateRange(fromIndex uint64, toIndex uint64, op func(word uint64, mask uint64) uint64, grow bool) {
+	if toIndex < fromIndex {
+		s.set.misuse(fmt.Errorf("%w: [%d, %d)", ErrInvalidRange, fromIndex, toIndex))
+		return
+	}
+	if fromIndex == toIndex {
+		return
+	}
+
+	s.update(fromIndex, toIndex-1, op, grow)
+}
+
+// update applies op to the bits from firstIndex to lastIndex (both inclusive)
+// a word at a time. If grow is false, the words past the end of the set are
+// left as they are.
+func (s *UintSet[I]) update(firstIndex uint64, lastIndex uint64, op func(word uint64, mask uint64) uint64, grow bool) {
+	limit := uint64(maxIndex)
+	if s.set.maxBits > 0 {
+		limit = uint64(s.set.maxBits) - 1
+	}
+	if lastIndex > limit {
+		s.outOfBounds(lastIndex)
+		lastIndex = limit
+	}
+	if firstIndex > lastIndex {
+		return
+	}
+
+	firstWord, lastWord := int(firstIndex/minBits), int(lastIndex/minBits)
+	if grow {
+		s.set.expandIfNeeded(lastWord)
+	} else {
+		lastWord = min(lastWord, len(s.set.arr)-1)
+	}
+
+	s.set.invalidate()
+	for arrIndex := firstWord; arrIndex <= lastWord; arrIndex++ {
+		mask := ^uint64(0)
+		if arrIndex == firstWord {
+			mask &= ^uint64(0) << (firstIndex % minBits)
+		}
+		if uint64(arrIndex) == lastIndex/minBits {
+			mask &= ^uint64(0) >> (minBits - 1 - lastIndex%minBits)
+		}
+		s.set.arr[arrIndex] = op(s.set.arr[arrIndex], mask)
+	}
+}
+
+// next returns the first bit with the specified value on or after fromIndex.
+// As all the bits outside the boundary are clear, a clear bit is always found.
+func (s *UintSet[I]) next(fromIndex uint64, value bool) (uint64, bool) {
+	arrIndex, ok := wordIndex(fromIndex)
+	if !ok || arrIndex >= len(s.set.arr) {
+		return fromIndex, !value
+	}
+
+	word := s.set.wordOf(arrIndex, value) & (^uint64(0) << (fromIndex % minBits))
+	for {
+		if word != 0 {
+			return uint64(arrIndex)*minBits + uint64(bits.TrailingZeros64(word)), true
+		}
+
+		arrIndex++
+		if arrIndex == len(s.set.arr) {
+			return uint64(arrIndex) * minBits, !value
+		}
+		word = s.set.wordOf(arrIndex, value)
+	}
+}
+
+func (s *UintSet[I]) checkIndex(index uint64) error {
+	if index > maxIndex || (s.set.maxBits > 0 && index >= uint64(s.set.maxBits)) {
+		return fmt.Errorf("%w: %d", ErrOutOfBounds, index)
+	}
+
+	return nil
+}
+
+// outOfBounds applies the bound policy on a write past the bound
+func (s *UintSet[I]) outOfBounds(index uint64) {
+	if s.set.policy == PanicOutOfBounds {
+		panic(fmt.Errorf("%w: %d is not in [0, %d)", ErrOutOfBounds, index, s.set.maxBits))
+	}
+}
+
+// wordIndex returns the index of the word holding the bit, ok is false
+// if the index is past maxIndex
+func wordIndex(index uint64) (int, bool) {
+	if index > maxIndex {
+		return 0, false
+	}
+	return int(index / minBits), true
+}
+
+// fromUint64 converts the index to I, ok is false if it doesn't fit
+func fromUint64[I Unsigned](index uint64) (I, bool) {
+	converted := I(index)
+	if uint64(converted) != index {
+		return 0, false
+	}
+	return converted, true
+}
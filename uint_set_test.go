@@ -0,0 +1,126 @@
+package bit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUintSet(t *testing.T) {
+	set, err := NewUintSet[uint32]()
+	if err != nil {
+		t.FailNow()
+	}
+
+	set.Set(3).Set(70).Flip(71).Flip(3)
+	assert.False(t, set.Get(3))
+	assert.True(t, set.Get(70))
+	assert.True(t, set.Get(71))
+	assert.False(t, set.Get(math.MaxUint32))
+	assert.Equal(t, 2, set.Cardinality())
+	assert.Equal(t, uint64(72), set.Length())
+
+	set.SetRange(10, 13).ClearRange(11, 12)
+	assert.Equal(t, "{10, 12, 70, 71}", set.String())
+
+	index, ok := set.NextSetBit(13)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(70), index)
+	_, ok = set.NextSetBit(72)
+	assert.False(t, ok)
+
+	index, ok = set.NextClearBit(70)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(72), index)
+
+	index, ok = set.PreviousSetBit(69)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(12), index)
+	_, ok = set.PreviousSetBit(9)
+	assert.False(t, ok)
+
+	var indices []uint32
+	set.Iterate(func(index uint32) bool {
+		indices = append(indices, index)
+		return index < 70
+	})
+	assert.Equal(t, []uint32{10, 12, 70}, indices)
+	assert.Equal(t, "{10, 12, 70, 71}", set.BitSet().String())
+}
+
+func TestUintSetOverflow(t *testing.T) {
+	set, _ := NewUintSet[uint64]()
+
+	huge := uint64(math.MaxUint64)
+	assert.NotPanics(t, func() { set.Set(huge).Clear(huge).Flip(huge) })
+	assert.False(t, set.Get(huge))
+	assert.True(t, set.IsEmpty())
+	assert.ErrorIs(t, set.TrySet(huge), ErrOutOfBounds)
+	assert.ErrorIs(t, set.TryClear(huge), ErrOutOfBounds)
+
+	index, ok := set.NextClearBit(huge)
+	assert.True(t, ok)
+	assert.Equal(t, huge, index)
+	_, ok = set.NextSetBit(huge)
+	assert.False(t, ok)
+
+	bounded, _ := NewUintSet[uint64](WithMaxBits(100), WithBoundPolicy(PanicOutOfBounds))
+	assert.Panics(t, func() { bounded.Set(huge) })
+	assert.NotPanics(t, func() { bounded.Set(99) })
+}
+
+func TestUintSetNarrowIndex(t *testing.T) {
+	underlying, _ := NewSet()
+	underlying.SetRange(0, 256).Set(300)
+
+	set := UintSetOf[uint8](underlying)
+	_, ok := set.NextClearBit(0)
+	assert.False(t, ok)
+
+	index, ok := set.PreviousSetBit(math.MaxUint8)
+	assert.True(t, ok)
+	assert.Equal(t, uint8(math.MaxUint8), index)
+
+	count := 0
+	set.Iterate(func(index uint8) bool {
+		count++
+		return true
+	})
+	assert.Equal(t, 256, count)
+	assert.Equal(t, 257, set.Cardinality())
+}
+
+func TestUintSetHighIndex(t *testing.T) {
+	// past the maximum value of int32, so it runs on GOARCH=386 too
+	high := uint32(1<<31 + 5)
+
+	set, _ := NewUintSet[uint32]()
+	assert.NoError(t, set.TrySet(high))
+	assert.True(t, set.Get(high))
+	assert.False(t, set.Get(high-1))
+	assert.Equal(t, uint64(high)+1, set.Length())
+
+	index, ok := set.NextSetBit(0)
+	assert.True(t, ok)
+	assert.Equal(t, high, index)
+	index, ok = set.PreviousSetBit(math.MaxUint32)
+	assert.True(t, ok)
+	assert.Equal(t, high, index)
+	index, ok = set.NextClearBit(high)
+	assert.True(t, ok)
+	assert.Equal(t, high+1, index)
+
+	set.SetRange(high-70, high+70).ClearRange(high-69, high+69)
+	var indices []uint32
+	set.Iterate(func(index uint32) bool {
+		indices = append(indices, index)
+		return true
+	})
+	assert.Equal(t, []uint32{high - 70, high + 69}, indices)
+	assert.Equal(t, "{2147483583, 2147483722}", set.String())
+
+	assert.NoError(t, set.TryClear(high+69))
+	set.Flip(high - 70)
+	assert.True(t, set.IsEmpty())
+}
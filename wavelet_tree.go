@@ -0,0 +1,229 @@
+package bit
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// WaveletTree is a static structure over a sequence of integers that answers
+// access, rank, select and range queries in O(log σ) time, where σ is the
+// largest symbol. It stores one Set per bit of the symbols, level by level
+// from the most significant bit (the levelwise layout also known as
+// wavelet matrix), and every level uses the rank and select index of Set.
+type WaveletTree struct {
+	n      int
+	levels []*Set
+	zeros  []int // number of clear bits on each level
+}
+
+// NewWaveletTree creates a wavelet tree from the sequence.
+func NewWaveletTree(sequence []uint32) *WaveletTree {
+	var maxSymbol uint32
+	for _, symbol := range sequence {
+		if symbol > maxSymbol {
+			maxSymbol = symbol
+		}
+	}
+
+	nlevels := bits.Len32(maxSymbol)
+	if nlevels == 0 {
+		nlevels = 1
+	}
+
+	wt := &WaveletTree{
+		n:      len(sequence),
+		levels: make([]*Set, nlevels),
+		zeros:  make([]int, nlevels),
+	}
+
+	current := make([]uint32, len(sequence))
+	copy(current, sequence)
+	next := make([]uint32, len(sequence))
+
+	for level := 0; level < nlevels; level++ {
+		shift := uint(nlevels - level - 1)
+		set, _ := NewSet(WithInitialBits(len(sequence)))
+
+		// stable partition: symbols with a clear bit go first
+		zeros := 0
+		for i, symbol := range current {
+			if (symbol>>shift)&1 == 1 {
+				set.Set(i)
+			} else {
+				next[zeros] = symbol
+				zeros++
+			}
+		}
+		ones := zeros
+		for _, symbol := range current {
+			if (symbol>>shift)&1 == 1 {
+				next[ones] = symbol
+				ones++
+			}
+		}
+
+		wt.levels[level] = set.BuildRankSelect()
+		wt.zeros[level] = zeros
+		current, next = next, current
+	}
+
+	return wt
+}
+
+// Len returns the length of the sequence.
+func (wt *WaveletTree) Len() int {
+	return wt.n
+}
+
+// Access returns the symbol at the specified index.
+// It panics if the index is out of range.
+func (wt *WaveletTree) Access(index int) uint32 {
+	if index < 0 || index >= wt.n {
+		panic(fmt.Errorf("%w: wavelet tree index %d", ErrOutOfBounds, index))
+	}
+
+	var symbol uint32
+	for level, set := range wt.levels {
+		symbol <<= 1
+		if set.Get(index) {
+			symbol |= 1
+			index = wt.zeros[level] + set.Rank1(index)
+		} else {
+			index = set.Rank0(index)
+		}
+	}
+
+	return symbol
+}
+
+// Rank returns the number of occurrences of the symbol before the
+// specified index (exclusive).
+func (wt *WaveletTree) Rank(symbol uint32, index int) int {
+	if index <= 0 || !wt.fits(symbol) {
+		return 0
+	}
+	if index > wt.n {
+		index = wt.n
+	}
+
+	start, end := 0, index
+	for level := range wt.levels {
+		start, end = wt.descend(level, wt.bit(symbol, level), start, end)
+	}
+
+	return end - start
+}
+
+// Select returns the index of the k-th occurrence of the symbol,
+// counting from zero. If there is no such occurrence, -1 is returned.
+func (wt *WaveletTree) Select(symbol uint32, k int) int {
+	if k < 0 || k >= wt.Rank(symbol, wt.n) {
+		return -1
+	}
+
+	// find where the symbol starts on the last level
+	start := 0
+	for level := range wt.levels {
+		start, _ = wt.descend(level, wt.bit(symbol, level), start, start)
+	}
+
+	// and go back up to the first level
+	index := start + k
+	for level := len(wt.levels) - 1; level >= 0; level-- {
+		if wt.bit(symbol, level) {
+			index = wt.levels[level].Select1(index - wt.zeros[level])
+		} else {
+			index = wt.levels[level].Select0(index)
+		}
+	}
+
+	return index
+}
+
+// Quantile returns the k-th smallest symbol, counting from zero, among the
+// symbols from fromIndex (inclusive) to toIndex (exclusive).
+// If the range has less than k+1 symbols, ok is false.
+func (wt *WaveletTree) Quantile(fromIndex int, toIndex int, k int) (symbol uint32, ok bool) {
+	fromIndex, toIndex = wt.clamp(fromIndex, toIndex)
+	if k < 0 || k >= toIndex-fromIndex {
+		return 0, false
+	}
+
+	for level, set := range wt.levels {
+		symbol <<= 1
+		zeros := set.Rank0(toIndex) - set.Rank0(fromIndex)
+		if k < zeros {
+			fromIndex, toIndex = wt.descend(level, false, fromIndex, toIndex)
+		} else {
+			k -= zeros
+			symbol |= 1
+			fromIndex, toIndex = wt.descend(level, true, fromIndex, toIndex)
+		}
+	}
+
+	return symbol, true
+}
+
+// RangeCount returns the number of symbols from fromIndex (inclusive) to
+// toIndex (exclusive) whose value is from lo (inclusive) to hi (exclusive).
+func (wt *WaveletTree) RangeCount(fromIndex int, toIndex int, lo uint32, hi uint32) int {
+	fromIndex, toIndex = wt.clamp(fromIndex, toIndex)
+	if lo >= hi || fromIndex >= toIndex {
+		return 0
+	}
+
+	return wt.countLess(fromIndex, toIndex, uint64(hi)) - wt.countLess(fromIndex, toIndex, uint64(lo))
+}
+
+// countLess returns the number of symbols less than x within the range
+func (wt *WaveletTree) countLess(fromIndex int, toIndex int, x uint64) int {
+	if x >= 1<<uint(len(wt.levels)) {
+		return toIndex - fromIndex
+	}
+
+	count := 0
+	for level, set := range wt.levels {
+		if wt.bit(uint32(x), level) {
+			count += set.Rank0(toIndex) - set.Rank0(fromIndex)
+			fromIndex, toIndex = wt.descend(level, true, fromIndex, toIndex)
+		} else {
+			fromIndex, toIndex = wt.descend(level, false, fromIndex, toIndex)
+		}
+	}
+
+	return count
+}
+
+// descend maps the range on the level to the range of the next level
+// containing the symbols with the specified bit
+func (wt *WaveletTree) descend(level int, bit bool, fromIndex int, toIndex int) (int, int) {
+	set := wt.levels[level]
+	if bit {
+		return wt.zeros[level] + set.Rank1(fromIndex), wt.zeros[level] + set.Rank1(toIndex)
+	}
+
+	return set.Rank0(fromIndex), set.Rank0(toIndex)
+}
+
+// bit returns the bit of the symbol which is stored on the level
+func (wt *WaveletTree) bit(symbol uint32, level int) bool {
+	return (symbol>>uint(len(wt.levels)-level-1))&1 == 1
+}
+
+// fits checks whether the symbol can be represented by the levels
+func (wt *WaveletTree) fits(symbol uint32) bool {
+	return uint64(symbol) < 1<<uint(len(wt.levels))
+}
+
+func (wt *WaveletTree) clamp(fromIndex int, toIndex int) (int, int) {
+	if fromIndex < 0 {
+		fromIndex = 0
+	}
+	if toIndex > wt.n {
+		toIndex = wt.n
+	}
+	if fromIndex > toIndex {
+		fromIndex = toIndex
+	}
+	return fromIndex, toIndex
+}
@@ -0,0 +1,136 @@
+package bit
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaveletTreeAccess(t *testing.T) {
+	testCases := [][]uint32{
+		{},
+		{0},
+		{0, 0, 0},
+		{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5},
+		{1 << 31, 7, 1<<32 - 1},
+	}
+
+	for _, sequence := range testCases {
+		wt := NewWaveletTree(sequence)
+		assert.Equal(t, len(sequence), wt.Len())
+		for i, symbol := range sequence {
+			assert.Equal(t, symbol, wt.Access(i))
+		}
+	}
+}
+
+func TestWaveletTreeRankSelect(t *testing.T) {
+	wt := NewWaveletTree([]uint32{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5})
+
+	testCases := []struct {
+		symbol uint32
+		index  int
+		rank   int
+	}{
+		{symbol: 5, index: 0, rank: 0},
+		{symbol: 5, index: 5, rank: 1},
+		{symbol: 5, index: 11, rank: 3},
+		{symbol: 5, index: 100, rank: 3},
+		{symbol: 1, index: 4, rank: 2},
+		{symbol: 7, index: 11, rank: 0},
+		{symbol: 100, index: 11, rank: 0},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.rank, wt.Rank(test.symbol, test.index))
+	}
+
+	assert.Equal(t, 4, wt.Select(5, 0))
+	assert.Equal(t, 8, wt.Select(5, 1))
+	assert.Equal(t, 10, wt.Select(5, 2))
+	assert.Equal(t, -1, wt.Select(5, 3))
+	assert.Equal(t, -1, wt.Select(5, -1))
+	assert.Equal(t, 1, wt.Select(1, 0))
+	assert.Equal(t, -1, wt.Select(7, 0))
+	assert.Equal(t, -1, wt.Select(100, 0))
+}
+
+func TestWaveletTreeQuantile(t *testing.T) {
+	wt := NewWaveletTree([]uint32{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5})
+
+	testCases := []struct {
+		fromIndex int
+		toIndex   int
+		k         int
+		symbol    uint32
+		ok        bool
+	}{
+		{fromIndex: 0, toIndex: 11, k: 0, symbol: 1, ok: true},
+		{fromIndex: 0, toIndex: 11, k: 10, symbol: 9, ok: true},
+		{fromIndex: 2, toIndex: 6, k: 1, symbol: 4, ok: true},
+		{fromIndex: 2, toIndex: 6, k: 4, symbol: 0, ok: false},
+		{fromIndex: 5, toIndex: 5, k: 0, symbol: 0, ok: false},
+	}
+
+	for _, test := range testCases {
+		symbol, ok := wt.Quantile(test.fromIndex, test.toIndex, test.k)
+		assert.Equal(t, test.symbol, symbol)
+		assert.Equal(t, test.ok, ok)
+	}
+}
+
+func TestWaveletTreeRangeCount(t *testing.T) {
+	wt := NewWaveletTree([]uint32{3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5})
+
+	assert.Equal(t, 11, wt.RangeCount(0, 11, 0, 100))
+	assert.Equal(t, 5, wt.RangeCount(0, 11, 4, 9))
+	assert.Equal(t, 2, wt.RangeCount(2, 6, 4, 9))
+	assert.Equal(t, 0, wt.RangeCount(2, 6, 9, 4))
+	assert.Equal(t, 1, wt.RangeCount(-5, 3, 0, 2))
+}
+
+func TestWaveletTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	sequence := make([]uint32, 300)
+	for i := range sequence {
+		sequence[i] = uint32(r.Intn(50))
+	}
+	wt := NewWaveletTree(sequence)
+
+	for i := 0; i < 200; i++ {
+		from := r.Intn(len(sequence))
+		to := from + r.Intn(len(sequence)-from+1)
+		lo := uint32(r.Intn(60))
+		hi := lo + uint32(r.Intn(20))
+
+		sorted := append([]uint32{}, sequence[from:to]...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		count := 0
+		for _, symbol := range sorted {
+			if symbol >= lo && symbol < hi {
+				count++
+			}
+		}
+		assert.Equal(t, count, wt.RangeCount(from, to, lo, hi))
+
+		if len(sorted) > 0 {
+			k := r.Intn(len(sorted))
+			symbol, ok := wt.Quantile(from, to, k)
+			assert.True(t, ok)
+			assert.Equal(t, sorted[k], symbol)
+		}
+
+		symbol := sequence[r.Intn(len(sequence))]
+		occurrences := 0
+		for j, s := range sequence[:to] {
+			if s == symbol {
+				assert.Equal(t, j, wt.Select(symbol, occurrences))
+				occurrences++
+			}
+		}
+		assert.Equal(t, occurrences, wt.Rank(symbol, to))
+	}
+}